@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -10,13 +12,83 @@ import (
 	screen "github.com/cheezecakee/ace/internal/ui/screens"
 )
 
+// Exit codes so scripts and hooks can tell failures apart
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `Usage:
+  ace                      start the TUI
+  ace pack verify <file>   verify a pack and print its report
+  ace pack repair <file>   repair missing IDs (use --dry-run to only show the diff)
+  ace pack compile <file>  compile Markdown notes into a pack
+  ace pack import <file>   build a pack from a CSV or TSV sheet
+  ace pack export <id>     export a pack for Anki or Quizlet
+  ace pack list            list all known packs
+  ace play [flags]         start a game without going through the menus
+
+Run "ace <command> -h" for the flags of a command.
+`
+
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		return runTUI()
+	}
+
+	switch args[0] {
+	case "pack":
+		return runPack(args[1:])
+	case "play":
+		return runPlay(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+}
+
+func runTUI() int {
 	ctx := context.NewContext()
 	model := screen.NewModel(ctx)
 
+	return runProgram(ctx, model)
+}
+
+// runProgram runs the TUI and suspends any unfinished session on the way
+// out, whether the player quit or the terminal went away
+func runProgram(ctx *context.Context, model tea.Model) int {
 	p := tea.NewProgram(model)
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+
+	// Bubbletea already handles SIGINT and SIGTERM
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	go func() {
+		if _, ok := <-hangup; ok {
+			p.Quit()
+		}
+	}()
+
+	_, runErr := p.Run()
+
+	if err := ctx.SuspendSession(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 	}
+
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
+		return exitError
+	}
+
+	return exitOK
 }
@@ -0,0 +1,520 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/pack"
+)
+
+const packUsage = `Usage:
+  ace pack verify <file>
+  ace pack repair [--dry-run] <file>
+  ace pack compile [-o <file>] <notes.md>
+  ace pack import [flags] <sheet.csv|sheet.tsv>
+  ace pack export --format anki|quizlet [-o <file>] <pack id>
+  ace pack list
+`
+
+func runPack(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	switch args[0] {
+	case "verify":
+		return runPackVerify(args[1:])
+	case "repair":
+		return runPackRepair(args[1:])
+	case "compile":
+		return runPackCompile(args[1:])
+	case "import":
+		return runPackImport(args[1:])
+	case "export":
+		return runPackExport(args[1:])
+	case "list":
+		return runPackList(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown pack command %q\n\n", args[0])
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+}
+
+func runPackVerify(args []string) int {
+	fs := flag.NewFlagSet("pack verify", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	path := fs.Arg(0)
+	raw, data, err := readRaw(path)
+	if err != nil {
+		printError(os.Stderr, path, err)
+		return exitError
+	}
+
+	report := raw.Verify()
+	printReport(os.Stdout, path, report, data)
+
+	if report.HasErrors() {
+		return exitError
+	}
+	return exitOK
+}
+
+func runPackRepair(args []string) int {
+	fs := flag.NewFlagSet("pack repair", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "print the changes without writing the file")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	path := fs.Arg(0)
+	raw, data, err := readRaw(path)
+	if err != nil {
+		printError(os.Stderr, path, err)
+		return exitError
+	}
+
+	repairReport := raw.Repair()
+
+	// The preview is against the file on disk, so it
+	// shows everything writing the pack would change
+	if repairReport.Repaired > 0 {
+		format, _ := pack.FormatOf(path)
+		raw.SchemaVersion = pack.SchemaVersion
+		after, err := raw.Rewrite(data, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			return exitError
+		}
+		printDiff(os.Stdout, path, string(data), string(after))
+	}
+	fmt.Printf("%s: %d repaired\n", path, repairReport.Repaired)
+
+	if !*dryRun && repairReport.Repaired > 0 {
+		if err := raw.Save(path); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			return exitError
+		}
+	}
+
+	// Whatever is left can't be fixed automatically
+	report := raw.Verify()
+	if report.HasErrors() {
+		printReport(os.Stdout, path, report, data)
+		return exitError
+	}
+
+	return exitOK
+}
+
+// runPackCompile turns notes into a pack file, IDs assigned and verified.
+// Nothing is written while the notes have errors
+func runPackCompile(args []string) int {
+	fs := flag.NewFlagSet("pack compile", flag.ContinueOnError)
+	out := fs.String("o", "", "file to write, the notes with a .json extension by default")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	path := fs.Arg(0)
+	raw, data, err := readRaw(path)
+	if err != nil {
+		printError(os.Stderr, path, err)
+		return exitError
+	}
+
+	raw.Repair()
+
+	report := raw.Verify()
+	printReport(os.Stdout, path, report, data)
+	if report.HasErrors() {
+		return exitError
+	}
+
+	dest := *out
+	if dest == "" {
+		dest = strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+	}
+	if format, ok := pack.FormatOf(dest); !ok || !format.Writable() {
+		fmt.Fprintf(os.Stderr, "%s: packs are written as .json, .yaml or .toml\n", dest)
+		return exitUsage
+	}
+
+	if err := raw.Save(dest); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", dest, err)
+		return exitError
+	}
+
+	fmt.Printf("%s: compiled to %s\n", path, dest)
+	return exitOK
+}
+
+// runPackImport builds a pack out of a spreadsheet, one question a row.
+// Rows that aren't a question are skipped, the rest still make the pack
+func runPackImport(args []string) int {
+	fs := flag.NewFlagSet("pack import", flag.ContinueOnError)
+	out := fs.String("o", "", "file to write, the sheet with a .json extension by default")
+	columns := fs.String("columns", "", "headers of the sheet's columns, e.g. prompt=Question,answer=Correct")
+	id := fs.String("id", "", "pack ID, generated when empty")
+	name := fs.String("name", "", "pack name, the sheet's file name by default")
+	role := fs.String("role", "", "role the pack is for")
+	creator := fs.String("creator", "", "who made the pack")
+	version := fs.String("version", "1.0.0", "pack version")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	cols := pack.DefaultColumns()
+	if *columns != "" {
+		for pair := range strings.SplitSeq(*columns, ",") {
+			field, header, ok := strings.Cut(pair, "=")
+			if !ok {
+				fmt.Fprintf(os.Stderr, "column %q should be field=header\n", pair)
+				return exitUsage
+			}
+			if err := cols.Set(strings.TrimSpace(field), strings.TrimSpace(header)); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				return exitUsage
+			}
+		}
+	}
+
+	path := fs.Arg(0)
+	data, err := pack.Read(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+		return exitError
+	}
+
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	if *name == "" {
+		*name = filepath.Base(base)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	raw := &pack.Raw{
+		ID:        *id,
+		Name:      *name,
+		Role:      *role,
+		Version:   *version,
+		Creator:   *creator,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(path), ".tsv") {
+		comma = '\t'
+	}
+
+	importReport, err := raw.Import(data, comma, cols)
+	if err != nil {
+		printError(os.Stderr, path, err)
+		return exitError
+	}
+
+	raw.Repair()
+
+	report := raw.Verify()
+	report.Warnings = append(importReport.Warnings, report.Warnings...)
+	printReport(os.Stdout, path, report, data)
+	if report.HasErrors() {
+		return exitError
+	}
+
+	dest := *out
+	if dest == "" {
+		dest = base + ".json"
+	}
+	if format, ok := pack.FormatOf(dest); !ok || !format.Writable() {
+		fmt.Fprintf(os.Stderr, "%s: packs are written as .json, .yaml or .toml\n", dest)
+		return exitUsage
+	}
+
+	if err := raw.Save(dest); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", dest, err)
+		return exitError
+	}
+
+	if skipped := len(importReport.Warnings); skipped > 0 {
+		fmt.Printf("%s: imported to %s, %d rows skipped\n", path, dest, skipped)
+		return exitOK
+	}
+	fmt.Printf("%s: imported to %s\n", path, dest)
+	return exitOK
+}
+
+// runPackExport writes a known pack as flashcards for another tool
+func runPackExport(args []string) int {
+	fs := flag.NewFlagSet("pack export", flag.ContinueOnError)
+	format := fs.String("format", "anki", "anki (tab separated notes) or quizlet (term and definition)")
+	out := fs.String("o", "", "file to write, stdout by default")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(os.Stderr, packUsage)
+		return exitUsage
+	}
+
+	m, err := loadMetadata()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	id := fs.Arg(0)
+	if _, ok := m.Packs[id]; !ok {
+		fmt.Fprintf(os.Stderr, "unknown pack %q, see ace pack list\n", id)
+		return exitError
+	}
+
+	p, err := m.LoadPack(id)
+	if err != nil {
+		printError(os.Stderr, id, err)
+		return exitError
+	}
+
+	var write func(io.Writer) error
+	switch *format {
+	case "anki":
+		write = p.WriteAnki
+	case "quizlet":
+		write = p.WriteQuizlet
+	default:
+		fmt.Fprintf(os.Stderr, "unknown export format %q\n", *format)
+		return exitUsage
+	}
+
+	if *out == "" {
+		if err := write(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", id, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *out, err)
+		return exitError
+	}
+
+	// Closing flushes the file, an export that didn't make it to disk failed
+	err = write(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", *out, err)
+		return exitError
+	}
+
+	return exitOK
+}
+
+func runPackList(args []string) int {
+	fs := flag.NewFlagSet("pack list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	m, err := loadMetadata()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	ids := m.PackIDs()
+	slices.Sort(ids)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tQUESTIONS\tVERSION")
+	for _, id := range ids {
+		info := m.Packs[id]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", info.ID, info.Name, info.Role, info.Count, info.Version)
+	}
+	if err := w.Flush(); err != nil {
+		return exitError
+	}
+
+	return exitOK
+}
+
+// readRaw unpacks the pack at path, the file's
+// contents come back too for printing snippets
+func readRaw(path string) (*pack.Raw, []byte, error) {
+	data, err := pack.Read(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	format, _ := pack.FormatOf(path)
+	raw, err := pack.Unpack(data, format)
+	return raw, data, err
+}
+
+// loadMetadata reads the saved metadata, rebuilding it from
+// the packs folder if it's missing
+func loadMetadata() (*pack.Metadata, error) {
+	m := &pack.Metadata{}
+	if err := m.Load(); err == nil {
+		return m, nil
+	}
+
+	packs, failed, err := pack.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range failed {
+		printError(os.Stderr, f.File, f.Err)
+	}
+
+	return pack.Build(packs), nil
+}
+
+func printReport(w io.Writer, path string, report pack.Report, data []byte) {
+	fmt.Fprintf(w, "%s: %d errors, %d warnings\n", path, len(report.Errors), len(report.Warnings))
+
+	for _, issue := range slices.Concat(report.Errors, report.Warnings) {
+		fmt.Fprintf(w, "  %s\n", issue)
+		if snippet := pack.Snippet(data, issue.Pos); snippet != "" {
+			fmt.Fprintln(w, indent(snippet, "    "))
+		}
+	}
+}
+
+// printError prints why a pack couldn't be read, with
+// the offending line when the error knows where it is
+func printError(w io.Writer, path string, err error) {
+	fmt.Fprintf(w, "%s: %v\n", path, err)
+
+	var srcErr *pack.SourceError
+	if errors.As(err, &srcErr) && srcErr.Snippet != "" {
+		fmt.Fprintln(w, indent(srcErr.Snippet, "  "))
+	}
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// printDiff prints the lines that differ between the file as it's on disk
+// and as it would be written, in hunks headed by the line they start on
+func printDiff(w io.Writer, path, before, after string) {
+	oldLines := strings.Split(strings.ReplaceAll(before, "\r\n", "\n"), "\n")
+	newLines := strings.Split(strings.ReplaceAll(after, "\r\n", "\n"), "\n")
+
+	header := false
+	for _, h := range diffLines(oldLines, newLines) {
+		if !header {
+			fmt.Fprintf(w, "--- %s\n+++ %s (repaired)\n", path, path)
+			header = true
+		}
+
+		fmt.Fprintf(w, "@@ line %d @@\n", h.line)
+		for _, l := range h.removed {
+			fmt.Fprintf(w, "-%s\n", l)
+		}
+		for _, l := range h.added {
+			fmt.Fprintf(w, "+%s\n", l)
+		}
+	}
+}
+
+// hunk is a run of lines replaced by others, line is where it starts in the old file
+type hunk struct {
+	line    int
+	removed []string
+	added   []string
+}
+
+// diffLines finds the hunks that turn a into b by their longest common
+// subsequence. The common start and end are cut first, repairs only touch
+// a few lines so what's left to compare is small
+func diffLines(a, b []string) []hunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	a, b = a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var (
+		hunks []hunk
+		open  *hunk
+	)
+	edit := func(i int) *hunk {
+		if open == nil {
+			hunks = append(hunks, hunk{line: prefix + i + 1})
+			open = &hunks[len(hunks)-1]
+		}
+		return open
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			open = nil
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			h := edit(i)
+			h.added = append(h.added, b[j])
+			j++
+		default:
+			h := edit(i)
+			h.removed = append(h.removed, a[i])
+			i++
+		}
+	}
+
+	return hunks
+}
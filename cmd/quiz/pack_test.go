@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []hunk
+	}{
+		{"same", "a\nb\nc", "a\nb\nc", nil},
+		{"added", "a\nc", "a\nb\nc", []hunk{{line: 2, added: []string{"b"}}}},
+		{"removed", "a\nb\nc", "a\nc", []hunk{{line: 2, removed: []string{"b"}}}},
+		{"changed", "a\nb\nc", "a\nB\nc", []hunk{{line: 2, removed: []string{"b"}, added: []string{"B"}}}},
+		{"two apart", "a\nb\nc\nd\ne", "a\nB\nc\nd\nE", []hunk{
+			{line: 2, removed: []string{"b"}, added: []string{"B"}},
+			{line: 5, removed: []string{"e"}, added: []string{"E"}},
+		}},
+		{"moved", "a\nb\nc", "b\nc\na", []hunk{
+			{line: 1, removed: []string{"a"}},
+			{line: 4, added: []string{"a"}},
+		}},
+		{"from nothing", "", "a", []hunk{{line: 1, removed: []string{""}, added: []string{"a"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffLines(strings.Split(tt.a, "\n"), strings.Split(tt.b, "\n"))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLines() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintDiffIgnoresLineEndings(t *testing.T) {
+	var buf bytes.Buffer
+	printDiff(&buf, "p.json", "{\r\n  \"id\": \"\"\r\n}\r\n", "{\n  \"id\": \"q\"\n}\n")
+
+	want := "--- p.json\n+++ p.json (repaired)\n@@ line 2 @@\n-  \"id\": \"\"\n+  \"id\": \"q\"\n"
+	if buf.String() != want {
+		t.Errorf("printDiff() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	printDiff(&buf, "p.json", "a\r\nb\r\n", "a\nb\n")
+	if buf.Len() != 0 {
+		t.Errorf("only the line endings differ, printed\n%s", buf.String())
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/pack"
+	"github.com/cheezecakee/ace/internal/ui/context"
+	screen "github.com/cheezecakee/ace/internal/ui/screens"
+)
+
+func runPlay(args []string) int {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	modeName := fs.String("mode", engine.StandardMode.String(), "game mode: standard, quick, rapid, hardcore, custom, review, flashcard")
+	difficultyName := fs.String("difficulty", engine.Entry.String(), "difficulty: entry, junior, mid, senior")
+	role := fs.String("role", "", "role to draw questions from (required)")
+	preset := fs.String("preset", "", "saved custom mode preset, implies --mode custom")
+	seed := fs.Int64("seed", 0, "selection seed to replay the same questions in the same order, 0 = the one in settings or random")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	mode, ok := engine.ParseMode(*modeName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown mode %q\n", *modeName)
+		return exitUsage
+	}
+
+	if *preset != "" {
+		mode = engine.CustomMode
+	}
+
+	difficulty := engine.ParseDifficulty(*difficultyName)
+	if !difficulty.IsValid() {
+		fmt.Fprintf(os.Stderr, "unknown difficulty %q\n", *difficultyName)
+		return exitUsage
+	}
+
+	if *role == "" {
+		fmt.Fprintln(os.Stderr, "--role is required")
+		fs.Usage()
+		return exitUsage
+	}
+
+	ctx := context.NewContext()
+	ctx.Mode = mode
+	if *seed != 0 {
+		ctx.Seed = *seed
+	}
+
+	if *preset != "" {
+		custom, ok := ctx.User.Presets[*preset]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown preset %q\n", *preset)
+			return exitUsage
+		}
+		ctx.Custom = &custom
+	}
+
+	format := ctx.GameMode().Format(difficulty)
+	if err := format.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid %s format: %v\n", mode, err)
+		return exitError
+	}
+	ctx.Format = format
+
+	if err := ctx.StartSession(pack.Role(*role)); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot start %s game for %q at %s: %v\n", mode, *role, difficulty, err)
+		return exitError
+	}
+
+	return runProgram(ctx, screen.NewGameModel(ctx))
+}
@@ -6,29 +6,44 @@ type Answer interface {
 }
 
 type ChoiceAnswer struct {
-	Selected int
+	Selected int `json:"selected"`
 }
 
 func (a ChoiceAnswer) Type() QuestionType { return Choice }
 func (a ChoiceAnswer) Value() any         { return a.Selected }
 
 type MultipleChoiceAnswer struct {
-	Selected []int
+	Selected []int `json:"selected"`
 }
 
 func (a MultipleChoiceAnswer) Type() QuestionType { return MultipleChoice }
 func (a MultipleChoiceAnswer) Value() any         { return a.Selected }
 
 type BoolAnswer struct {
-	Answer bool
+	Answer bool `json:"answer"`
 }
 
 func (a BoolAnswer) Type() QuestionType { return Bool }
 func (a BoolAnswer) Value() any         { return a.Answer }
 
 type TextEntryAnswer struct {
-	Text string
+	Text   string `json:"text"`
+	Rating Rating `json:"rating,omitempty"` // the player's own grade, when they give one
 }
 
 func (a TextEntryAnswer) Type() QuestionType { return TextEntry }
 func (a TextEntryAnswer) Value() any         { return a.Text }
+
+type ShortAnswerAnswer struct {
+	Text string `json:"text"`
+}
+
+func (a ShortAnswerAnswer) Type() QuestionType { return ShortAnswer }
+func (a ShortAnswerAnswer) Value() any         { return a.Text }
+
+type OrderingAnswer struct {
+	Order []int `json:"order"` // item indices in the order they were put in
+}
+
+func (a OrderingAnswer) Type() QuestionType { return Ordering }
+func (a OrderingAnswer) Value() any         { return a.Order }
@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DescribeAnswer renders an answer to q the way a player reads it,
+// options by their text rather than by index. Letters are left out
+// since they change when options are shuffled
+func DescribeAnswer(q Question, a Answer) string {
+	if a == nil {
+		return "no answer"
+	}
+
+	switch q := q.(type) {
+	case ChoiceQuestion:
+		selected, ok := a.Value().(int)
+		if !ok {
+			return ""
+		}
+		return describeOption(q.Options, selected)
+
+	case MultipleChoiceQuestion:
+		selected, ok := a.Value().([]int)
+		if !ok {
+			return ""
+		}
+		if len(selected) == 0 {
+			return "nothing selected"
+		}
+		return describeOptions(q.Options, selected)
+
+	case OrderingQuestion:
+		order, ok := a.Value().([]int)
+		if !ok {
+			return ""
+		}
+		parts := make([]string, 0, len(order))
+		for _, i := range order {
+			parts = append(parts, describeOption(q.Items, i))
+		}
+		return strings.Join(parts, " → ")
+
+	case BoolQuestion:
+		answer, ok := a.Value().(bool)
+		if !ok {
+			return ""
+		}
+		if answer {
+			return "True"
+		}
+		return "False"
+
+	default:
+		return fmt.Sprint(a.Value())
+	}
+}
+
+func describeOption(options []string, i int) string {
+	if i < 0 || i >= len(options) {
+		return "?"
+	}
+	return options[i]
+}
+
+func describeOptions(options []string, indices []int) string {
+	parts := make([]string, 0, len(indices))
+	for _, i := range indices {
+		parts = append(parts, describeOption(options, i))
+	}
+	return strings.Join(parts, ", ")
+}
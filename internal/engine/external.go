@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DefaultGradeTimeout is how long a text backend gets before the
+// answer is graded by keywords instead
+const DefaultGradeTimeout = 5 * time.Second
+
+// TextBackend grades free text answers outside the engine, a local model
+// or a rubric script. It's the only part of grading that leaves the process
+type TextBackend interface {
+	GradeText(ctx context.Context, req GradeRequest) (GradeResponse, error)
+}
+
+// GradeRequest is what a text backend is sent for every answer
+type GradeRequest struct {
+	Prompt   string           `json:"prompt"`
+	Expected string           `json:"expected"`
+	Keywords []RequestKeyword `json:"keywords"`
+	Answer   string           `json:"answer"`
+}
+
+type RequestKeyword struct {
+	Term     string   `json:"term"`
+	Synonyms []string `json:"synonyms,omitempty"`
+	Weight   float32  `json:"weight"`
+}
+
+// GradeResponse is what a text backend answers with
+type GradeResponse struct {
+	Accuracy float32 `json:"accuracy"` // 0-1
+	Feedback string  `json:"feedback"`
+	Correct  *bool   `json:"correct,omitempty"` // defaults to a full score
+}
+
+// NewGradeRequest builds the request for answer to q
+func NewGradeRequest(q TextEntryQuestion, answer string) GradeRequest {
+	keywords := make([]RequestKeyword, len(q.Keywords))
+	for i, k := range q.Keywords {
+		keywords[i] = RequestKeyword{
+			Term:     k.Term,
+			Synonyms: k.Synonyms,
+			Weight:   k.weight(),
+		}
+	}
+
+	return GradeRequest{
+		Prompt:   q.Prompt,
+		Expected: q.ExpectedAnswer,
+		Keywords: keywords,
+		Answer:   answer,
+	}
+}
+
+// ExternalGrader sends text answers to Backend and grades everything
+// else with Fallback. When the backend fails or takes longer than
+// Timeout the answer falls back too, so a broken backend never blocks a game.
+// Grade waits on the backend, the game grades through a session.Submission
+// so the wait happens off the UI loop and without the session locked
+type ExternalGrader struct {
+	Backend  TextBackend
+	Timeout  time.Duration // 0 = DefaultGradeTimeout
+	Fallback GradePolicy
+}
+
+func (g *ExternalGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
+	textQ, ok := q.(TextEntryQuestion)
+	textA, isText := a.(TextEntryAnswer)
+	if !ok || !isText || g.Backend == nil {
+		return g.fallback(q, a, at)
+	}
+
+	timeout := g.Timeout
+	if timeout <= 0 {
+		timeout = DefaultGradeTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := g.Backend.GradeText(ctx, NewGradeRequest(textQ, textA.Text))
+	if err != nil {
+		res := g.fallback(q, a, at)
+		if acc, ok := res.(AccuracyResult); ok {
+			notes := []string{"Grader unavailable, graded by keywords"}
+			if acc.Feedback != "" {
+				notes = append(notes, acc.Feedback)
+			}
+			acc.Feedback = strings.Join(notes, "; ")
+			return acc
+		}
+		return res
+	}
+
+	accuracy := min(max(resp.Accuracy, 0), 1)
+	correct := accuracy == 1
+	if resp.Correct != nil {
+		correct = *resp.Correct
+	}
+
+	return AccuracyResult{
+		Correct:  correct,
+		Accuracy: accuracy,
+		Feedback: resp.Feedback,
+	}
+}
+
+func (g *ExternalGrader) fallback(q Question, a Answer, at Attempt) GradeResult {
+	if g.Fallback == nil {
+		return (&AccuracyGrader{}).Grade(q, a, at)
+	}
+	return g.Fallback.Grade(q, a, at)
+}
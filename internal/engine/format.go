@@ -54,6 +54,16 @@ func (f Format) Validate() error {
 		return errors.New("start life must be > 0")
 	}
 
+	// --- Progression ---
+	if f.Progression.Mode == Scaling {
+		if f.Progression.Streak < 0 || f.Progression.Window < 0 {
+			return errors.New("scaling streak and window cannot be negative")
+		}
+		if f.Progression.Window > 0 && (f.Progression.Accuracy <= 0 || f.Progression.Accuracy > 1) {
+			return errors.New("scaling accuracy must be between 0 and 1")
+		}
+	}
+
 	// --- Questions ---
 	if f.Question.Count < 0 {
 		return errors.New("max questions cannot be negative")
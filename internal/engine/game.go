@@ -13,6 +13,8 @@ const (
 	RapidMode
 	HardcoreMode
 	CustomMode
+	ReviewMode
+	FlashcardMode
 )
 
 func (m ModeID) String() string {
@@ -27,11 +29,36 @@ func (m ModeID) String() string {
 		return "hardcore"
 	case CustomMode:
 		return "custom"
+	case ReviewMode:
+		return "review"
+	case FlashcardMode:
+		return "flashcard"
 	default:
 		return ""
 	}
 }
 
+func ParseMode(s string) (ModeID, bool) {
+	switch s {
+	case "standard":
+		return StandardMode, true
+	case "quick":
+		return QuickMode, true
+	case "rapid":
+		return RapidMode, true
+	case "hardcore":
+		return HardcoreMode, true
+	case "custom":
+		return CustomMode, true
+	case "review":
+		return ReviewMode, true
+	case "flashcard":
+		return FlashcardMode, true
+	default:
+		return 0, false
+	}
+}
+
 type Difficulty int
 
 const (
@@ -85,6 +112,8 @@ const (
 	MultipleChoice
 	TextEntry
 	Bool
+	ShortAnswer
+	Ordering
 )
 
 func (qt QuestionType) String() string {
@@ -97,6 +126,10 @@ func (qt QuestionType) String() string {
 		return "text entry"
 	case Bool:
 		return "bool"
+	case ShortAnswer:
+		return "short answer"
+	case Ordering:
+		return "ordering"
 	default:
 		return "unknown"
 	}
@@ -111,6 +144,21 @@ const (
 	Fifty
 )
 
+func (qc QuestionCount) String() string {
+	switch qc {
+	case AllQuestions:
+		return "all"
+	case Ten:
+		return "10"
+	case Thirty:
+		return "30"
+	case Fifty:
+		return "50"
+	default:
+		return ""
+	}
+}
+
 func (qc QuestionCount) Int() int {
 	switch qc {
 	case Ten:
@@ -197,3 +245,16 @@ const (
 	FixedLives
 	SuddenDeath
 )
+
+func (lm LifeMode) String() string {
+	switch lm {
+	case NoLives:
+		return "none"
+	case FixedLives:
+		return "fixed"
+	case SuddenDeath:
+		return "sudden death"
+	default:
+		return ""
+	}
+}
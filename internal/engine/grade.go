@@ -1,7 +1,18 @@
 package engine
 
+import "time"
+
 type GradePolicy interface {
-	Grade(q Question, a Answer) GradeResult
+	Grade(q Question, a Answer, at Attempt) GradeResult
+}
+
+// Attempt is how an answer was given, for
+// graders that reward speed or streaks
+type Attempt struct {
+	Difficulty Difficulty
+	Remaining  time.Duration // time left on the question when answered
+	Limit      time.Duration // time allowed per question, 0 = unlimited
+	Streak     int           // correct answers in a row before this one
 }
 
 type GradeResult interface {
@@ -15,24 +26,32 @@ const (
 	Accuracy GradeType = iota + 1
 	Binary
 	Score
+	Practice
+	SelfRated
 )
 
 func GetGrader(mode ModeID) GradePolicy {
 	switch mode {
 	case StandardMode:
-		return &AccuracyGrader{}
+		return &AccuracyGrader{Selection: Proportional}
 
 	case QuickMode:
 		return &BinaryGrader{}
 
 	case RapidMode:
-		return &ScoreGrader{}
+		return &ScoreGrader{Selection: Penalty, Ordering: ByPosition}
 
 	case HardcoreMode:
 		return &BinaryGrader{}
 
 	case CustomMode:
-		return &PracticeGrader{}
+		return &PracticeGrader{Selection: Proportional}
+
+	case ReviewMode:
+		return &AccuracyGrader{Selection: Proportional}
+
+	case FlashcardMode:
+		return &SelfGrader{Fallback: &AccuracyGrader{Selection: Proportional}}
 
 	default:
 		return &BinaryGrader{}
@@ -40,9 +59,10 @@ func GetGrader(mode ModeID) GradePolicy {
 }
 
 type AccuracyResult struct {
-	Correct  bool
-	Accuracy float32 // 0-1
-	Feedback string  // What was good/missing
+	Correct  bool    `json:"correct"`
+	Accuracy float32 `json:"accuracy"` // 0-1
+	Feedback string  `json:"feedback"` // What was good/missing
+	Picks
 }
 
 func (r AccuracyResult) IsCorrect() bool { return r.Correct }
@@ -50,7 +70,8 @@ func (r AccuracyResult) IsCorrect() bool { return r.Correct }
 func (r AccuracyResult) Type() GradeType { return Accuracy }
 
 type BinaryResult struct {
-	Correct bool
+	Correct bool `json:"correct"`
+	Picks
 }
 
 func (r BinaryResult) IsCorrect() bool { return r.Correct }
@@ -58,20 +79,40 @@ func (r BinaryResult) IsCorrect() bool { return r.Correct }
 func (r BinaryResult) Type() GradeType { return Binary }
 
 type ScoreResult struct {
-	Correct      bool
-	PointsEarned int
-	MaxPoints    int
-	Multiplier   float32 // eg., 1.5x for speed bonus
+	Correct      bool    `json:"correct"`
+	PointsEarned int     `json:"points_earned"`
+	MaxPoints    int     `json:"max_points"`
+	Multiplier   float32 `json:"multiplier"` // eg., 1.5x for speed bonus
+	Picks
 }
 
 func (r ScoreResult) IsCorrect() bool { return r.Correct }
 
 func (r ScoreResult) Type() GradeType { return Score }
 
+// Points is what a result adds to the session score, the points
+// earned for scored results and one per correct answer otherwise
+func Points(r GradeResult) int {
+	if r == nil {
+		return 0
+	}
+
+	if res, ok := r.(ScoreResult); ok {
+		return res.PointsEarned
+	}
+
+	if r.IsCorrect() {
+		return 1
+	}
+	return 0
+}
+
 type PracticeResult struct {
-	CorrectAnswer string
-	Explanation   string
+	Correct       bool   `json:"correct"` // Whether the answer matched, practice never costs anything
+	CorrectAnswer string `json:"correct_answer"`
+	Explanation   string `json:"explanation"`
+	Picks
 }
 
-func (r PracticeResult) IsCorrect() bool { return true } // Always "correct" in practice
-func (r PracticeResult) Type() GradeType { return 0 }    // Zero value
+func (r PracticeResult) IsCorrect() bool { return r.Correct }
+func (r PracticeResult) Type() GradeType { return Practice }
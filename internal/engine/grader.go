@@ -1,13 +1,13 @@
 package engine
 
 import (
-	"slices"
+	"math"
 	"strings"
 )
 
 type BinaryGrader struct{}
 
-func (g *BinaryGrader) Grade(q Question, a Answer) GradeResult {
+func (g *BinaryGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
 	expected := q.GetAnswer()
 
 	// Compare the answers
@@ -17,23 +17,62 @@ func (g *BinaryGrader) Grade(q Question, a Answer) GradeResult {
 			Correct: expected.Value() == a.Value(),
 		}
 	case MultipleChoice:
-		exp := expected.Value().([]int)
-		usr := a.Value().([]int)
+		exp, _ := expected.Value().([]int)
+		usr, _ := a.Value().([]int)
+		_, picks := GradeSelection(exp, usr, AllOrNothing)
 		return BinaryResult{
-			Correct: slices.Equal(exp, usr),
+			Correct: picks.IsEmpty(),
+			Picks:   picks,
+		}
+	case ShortAnswer:
+		shortQ, _ := q.(ShortAnswerQuestion)
+		text, _ := a.Value().(string)
+		return BinaryResult{
+			Correct: shortQ.Matches(text),
+		}
+	case Ordering:
+		orderQ, _ := q.(OrderingQuestion)
+		order, _ := a.Value().([]int)
+		return BinaryResult{
+			Correct: inOrder(order, len(orderQ.Items)),
 		}
 	}
 
 	return BinaryResult{Correct: false}
 }
 
-// AccuracyGrader - for TextEntry with partial credit
-type AccuracyGrader struct{}
+// AccuracyGrader - for TextEntry with partial credit, Selection
+// and Ordering decide the credit for multiple choice and ordering
+type AccuracyGrader struct {
+	Selection SelectionPolicy
+	Ordering  OrderPolicy
+}
+
+func (g *AccuracyGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
+	if orderQ, ok := q.(OrderingQuestion); ok {
+		order, _ := a.Value().([]int)
+		return AccuracyResult{
+			Correct:  inOrder(order, len(orderQ.Items)),
+			Accuracy: float32(GradeOrder(order, len(orderQ.Items), g.Ordering)),
+		}
+	}
 
-func (g *AccuracyGrader) Grade(q Question, a Answer) GradeResult {
-	// for non-text questions, treat as binary
+	if q.Type() == MultipleChoice {
+		exp, _ := q.GetAnswer().Value().([]int)
+		usr, _ := a.Value().([]int)
+		credit, picks := GradeSelection(exp, usr, g.Selection)
+
+		return AccuracyResult{
+			Correct:  picks.IsEmpty(),
+			Accuracy: float32(credit),
+			Feedback: describePicks(q, picks),
+			Picks:    picks,
+		}
+	}
+
+	// for other non-text questions, treat as binary
 	if q.Type() != TextEntry {
-		binary := (&BinaryGrader{}).Grade(q, a)
+		binary := (&BinaryGrader{}).Grade(q, a, at)
 		isCorrect := binary.IsCorrect()
 
 		accuracy := float32(0)
@@ -48,8 +87,7 @@ func (g *AccuracyGrader) Grade(q Question, a Answer) GradeResult {
 		}
 	}
 
-	// For TextEntry, do keyword matching (placeholder until AI)
-	// TextEntry grading
+	// TextEntry grading, keyword matching that runs offline
 	textQ, ok := q.(TextEntryQuestion)
 	if !ok {
 		return AccuracyResult{Correct: false}
@@ -60,54 +98,140 @@ func (g *AccuracyGrader) Grade(q Question, a Answer) GradeResult {
 		return AccuracyResult{Correct: false}
 	}
 
-	answerText := strings.ToLower(textA.Text)
+	match := MatchText(textQ, textA.Text)
 
-	// Count how many keywords are present
-	keywordsFound := 0
-	for _, keyword := range textQ.Keywords {
-		if strings.Contains(answerText, strings.ToLower(keyword)) {
-			keywordsFound++
-		}
+	var feedback []string
+	if len(match.Found) > 0 {
+		feedback = append(feedback, "Matched: "+strings.Join(match.Found, ", "))
 	}
-
-	accuracy := float32(0)
-	if len(textQ.Keywords) > 0 {
-		accuracy = float32(keywordsFound) / float32(len(textQ.Keywords))
+	if len(match.Missing) > 0 {
+		feedback = append(feedback, "Missing: "+strings.Join(match.Missing, ", "))
 	}
 
 	return AccuracyResult{
-		Correct:  accuracy == 1.0,
-		Accuracy: accuracy,
-		Feedback: "",
+		Correct:  match.Accuracy == 1.0,
+		Accuracy: match.Accuracy,
+		Feedback: strings.Join(feedback, "; "),
 	}
 }
 
-type ScoreGrader struct{}
+// Scoring knobs for ScoreGrader
+const (
+	maxSpeedBonus = 0.5 // answering instantly is worth 1.5x
+	comboStep     = 0.1 // each answer in the streak adds 10%
+	maxCombo      = 5   // up to 1.5x
+)
 
-func (g *ScoreGrader) Grade(q Question, a Answer) GradeResult {
-	expected := q.GetAnswer()
+// ScoreGrader - for Rapid, points scale with difficulty, speed and
+// streaks, multiple choice and ordering earn credit by Selection and Ordering
+type ScoreGrader struct {
+	Selection SelectionPolicy
+	Ordering  OrderPolicy
+}
 
-	// Compare the answers
+func (g *ScoreGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
+	correct, credit, picks := g.credit(q, a, at)
+
+	base := float64(basePoints(at.Difficulty))
+	multiplier := speedMultiplier(at) * comboMultiplier(at)
+
+	// The most the answer could have earned, full credit with no time used
+	best := at
+	best.Remaining = at.Limit
+	most := speedMultiplier(best) * comboMultiplier(at)
+
+	return ScoreResult{
+		Correct:      correct,
+		PointsEarned: int(math.Round(base * credit * float64(multiplier))),
+		MaxPoints:    int(math.Round(base * float64(most))),
+		Multiplier:   multiplier,
+		Picks:        picks,
+	}
+}
+
+// credit is the share of the base points the answer earns, 0-1
+func (g *ScoreGrader) credit(q Question, a Answer, at Attempt) (bool, float64, Picks) {
 	switch q.Type() {
-	case Choice, Bool, TextEntry:
-		return ScoreResult{
-			Correct: expected.Value() == a.Value(),
-		}
 	case MultipleChoice:
-		exp := expected.Value().([]int)
-		usr := a.Value().([]int)
-		return ScoreResult{
-			Correct: slices.Equal(exp, usr),
+		exp, _ := q.GetAnswer().Value().([]int)
+		usr, _ := a.Value().([]int)
+		credit, picks := GradeSelection(exp, usr, g.Selection)
+		return picks.IsEmpty(), credit, picks
+
+	case TextEntry:
+		res, _ := (&AccuracyGrader{}).Grade(q, a, at).(AccuracyResult)
+		return res.Correct, float64(res.Accuracy), Picks{}
+
+	case Ordering:
+		res, _ := (&AccuracyGrader{Ordering: g.Ordering}).Grade(q, a, at).(AccuracyResult)
+		return res.Correct, float64(res.Accuracy), Picks{}
+
+	default:
+		correct := (&BinaryGrader{}).Grade(q, a, at).IsCorrect()
+		if correct {
+			return true, 1, Picks{}
 		}
+		return false, 0, Picks{}
+	}
+}
+
+func basePoints(d Difficulty) int {
+	switch d {
+	case Junior:
+		return 150
+	case Mid:
+		return 200
+	case Senior:
+		return 300
+	default:
+		return 100
+	}
+}
+
+// speedMultiplier rewards whatever was left of the per-question time
+func speedMultiplier(at Attempt) float32 {
+	if at.Limit <= 0 {
+		return 1
 	}
 
-	return ScoreResult{Correct: false}
+	left := min(max(float64(at.Remaining)/float64(at.Limit), 0), 1)
+	return float32(1 + maxSpeedBonus*left)
+}
+
+func comboMultiplier(at Attempt) float32 {
+	return float32(1 + comboStep*float64(min(at.Streak, maxCombo)))
 }
 
-type PracticeGrader struct{}
+type PracticeGrader struct {
+	Selection SelectionPolicy
+	Ordering  OrderPolicy
+}
+
+func (g *PracticeGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
+	// Matched the same way Standard grades, so practice still counts in stats
+	matched, _ := (&AccuracyGrader{Selection: g.Selection, Ordering: g.Ordering}).Grade(q, a, at).(AccuracyResult)
 
-func (g *PracticeGrader) Grade(q Question, a Answer) GradeResult {
 	return PracticeResult{
-		CorrectAnswer: q.GetAnswer().Value().(string),
+		Correct:       matched.Correct,
+		CorrectAnswer: DescribeAnswer(q, q.GetAnswer()),
+		Explanation:   q.GetNotes().Explanation,
+		Picks:         matched.Picks,
+	}
+}
+
+// describePicks spells out the options a selection got wrong
+func describePicks(q Question, picks Picks) string {
+	mq, ok := q.(MultipleChoiceQuestion)
+	if !ok || picks.IsEmpty() {
+		return ""
+	}
+
+	var parts []string
+	if len(picks.Missed) > 0 {
+		parts = append(parts, "Missed: "+describeOptions(mq.Options, picks.Missed))
+	}
+	if len(picks.Wrong) > 0 {
+		parts = append(parts, "Wrong: "+describeOptions(mq.Options, picks.Wrong))
 	}
+	return strings.Join(parts, "; ")
 }
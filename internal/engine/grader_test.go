@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoreGrader(t *testing.T) {
+	choice := ChoiceQuestion{BaseQuestion: BaseQuestion{ID: "port"}, Options: []string{"21", "22"}, Correct: 1}
+	multi := MultipleChoiceQuestion{BaseQuestion: BaseQuestion{ID: "transport"}, Options: []string{"TCP", "HTTP", "UDP"}, Correct: []int{0, 2}}
+
+	tests := []struct {
+		name   string
+		q      Question
+		a      Answer
+		at     Attempt
+		points int
+		max    int
+	}{
+		{"unrated", choice, ChoiceAnswer{Selected: 1}, Attempt{}, 100, 100},
+		{"senior", choice, ChoiceAnswer{Selected: 1}, Attempt{Difficulty: Senior}, 300, 300},
+		{"instant with a streak", choice, ChoiceAnswer{Selected: 1}, Attempt{Difficulty: Junior, Remaining: 10 * time.Second, Limit: 10 * time.Second, Streak: 2}, 270, 270},
+		{"half the time left", choice, ChoiceAnswer{Selected: 1}, Attempt{Difficulty: Junior, Remaining: 5 * time.Second, Limit: 10 * time.Second, Streak: 2}, 225, 270},
+		{"wrong", choice, ChoiceAnswer{Selected: 0}, Attempt{Difficulty: Junior, Remaining: 5 * time.Second, Limit: 10 * time.Second, Streak: 2}, 0, 270},
+		{"streak capped", choice, ChoiceAnswer{Selected: 1}, Attempt{Difficulty: Mid, Streak: 20}, 300, 300},
+		{"partial selection", multi, MultipleChoiceAnswer{Selected: []int{0}}, Attempt{Difficulty: Mid}, 100, 200},
+	}
+
+	grader := &ScoreGrader{Selection: Proportional}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, ok := grader.Grade(tt.q, tt.a, tt.at).(ScoreResult)
+			if !ok {
+				t.Fatalf("Grade didn't return a ScoreResult")
+			}
+			if res.PointsEarned != tt.points {
+				t.Errorf("points = %d, want %d", res.PointsEarned, tt.points)
+			}
+			if res.MaxPoints != tt.max {
+				t.Errorf("max points = %d, want %d", res.MaxPoints, tt.max)
+			}
+			if res.PointsEarned > res.MaxPoints {
+				t.Errorf("earned %d of at most %d", res.PointsEarned, res.MaxPoints)
+			}
+		})
+	}
+}
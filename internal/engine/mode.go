@@ -9,7 +9,7 @@ type Mode interface {
 func GetGameMode(mode ModeID) Mode {
 	switch mode {
 	case CustomMode:
-		return newCustomMode()
+		return NewCustomMode()
 	case RapidMode:
 		return newRapidMode()
 	case QuickMode:
@@ -18,6 +18,10 @@ func GetGameMode(mode ModeID) Mode {
 		return newHardcoreMode()
 	case StandardMode:
 		return newStandardMode()
+	case ReviewMode:
+		return newReviewMode()
+	case FlashcardMode:
+		return newFlashcardMode()
 	default:
 		return nil
 	}
@@ -39,10 +43,11 @@ func (gm *Standard) Format(difficulty Difficulty) Format {
 			NoLives,
 			LifeOptions{},
 		),
-		Progression: ProgressionRules{
-			Mode:       Scaling,
-			Difficulty: difficulty,
-		},
+		Progression: BuildProgressionRules(
+			Scaling,
+			difficulty,
+			ProgressionOptions{},
+		),
 		Question: QuestionRules{
 			Types:     QuestionTypeSet{TextEntry},
 			Randomize: true,
@@ -69,12 +74,13 @@ func (gm *Quick) Format(difficulty Difficulty) Format {
 			NoLives,
 			LifeOptions{},
 		),
-		Progression: ProgressionRules{
-			Mode:       Fixed,
-			Difficulty: difficulty,
-		},
+		Progression: BuildProgressionRules(
+			Fixed,
+			difficulty,
+			ProgressionOptions{},
+		),
 		Question: QuestionRules{
-			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool},
+			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool, ShortAnswer, Ordering},
 			Randomize: true,
 		},
 		Description: "Quick-fire questions to warm up before interviews",
@@ -100,12 +106,13 @@ func (gm *Rapid) Format(difficulty Difficulty) Format {
 			NoLives,
 			LifeOptions{},
 		),
-		Progression: ProgressionRules{
-			Mode:       Fixed,
-			Difficulty: difficulty,
-		},
+		Progression: BuildProgressionRules(
+			Fixed,
+			difficulty,
+			ProgressionOptions{},
+		),
 		Question: QuestionRules{
-			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool},
+			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool, ShortAnswer, Ordering},
 			Randomize: true,
 		},
 		Description: "Fast-paced reaction training",
@@ -123,54 +130,122 @@ func (gm *Hardcore) Format(difficulty Difficulty) Format {
 		Time: BuildTimeRules(
 			PerQuestionWithBonus,
 			TimeOptions{
-				PerQuestion: 30 * time.Second,
-				Bonus:       10 * time.Second,
+				PerQuestion:  30 * time.Second,
+				Bonus:        10 * time.Second,
+				DisablePause: true, // No breathers in survival
 			},
 		),
 		Lives: BuildLifeRules(
 			SuddenDeath,
 			LifeOptions{},
 		),
-		Progression: ProgressionRules{
-			Mode:       Scaling,
-			Difficulty: Entry,
-		},
+		Progression: BuildProgressionRules(
+			Scaling,
+			Entry,
+			ProgressionOptions{},
+		),
 		Question: QuestionRules{
-			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool},
+			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool, ShortAnswer, Ordering},
 			Randomize: true,
 		},
 		Description: "High-pressure survival mode",
 	}
 }
 
+type Review struct{}
+
+func newReviewMode() *Review {
+	return &Review{}
+}
+
+// Format ignores the difficulty, due questions are
+// picked from every difficulty by the review scheduler
+func (gm *Review) Format(difficulty Difficulty) Format {
+	return Format{
+		Time: BuildTimeRules(
+			Unlimited,
+			TimeOptions{},
+		),
+		Lives: BuildLifeRules(
+			NoLives,
+			LifeOptions{},
+		),
+		Progression: BuildProgressionRules(
+			Fixed,
+			0,
+			ProgressionOptions{},
+		),
+		Question: QuestionRules{
+			Types:     QuestionTypeSet{Choice, MultipleChoice, Bool, TextEntry, ShortAnswer, Ordering},
+			Randomize: false,
+		},
+		Description: "Spaced repetition of the questions you keep missing",
+	}
+}
+
+type Flashcard struct{}
+
+func newFlashcardMode() *Flashcard {
+	return &Flashcard{}
+}
+
+func (gm *Flashcard) Format(difficulty Difficulty) Format {
+	return Format{
+		Time: BuildTimeRules(
+			Unlimited,
+			TimeOptions{},
+		),
+		Lives: BuildLifeRules(
+			NoLives,
+			LifeOptions{},
+		),
+		Progression: BuildProgressionRules(
+			Fixed,
+			difficulty,
+			ProgressionOptions{},
+		),
+		Question: QuestionRules{
+			Types:     QuestionTypeSet{TextEntry},
+			Randomize: true,
+			SelfGrade: true,
+		},
+		Description: "Flashcards, compare with the expected answer and rate yourself",
+	}
+}
+
 type Custom struct {
-	Control TimeMode // default Unlimited
+	Control TimeMode `json:"control"` // default Unlimited
 
 	// Time settings
-	TotalDuration int // seconds
-	PerQuestion   int // seconds
-	Bonus         int // seconds
-	Penalty       int // seconds
+	TotalDuration int `json:"total_duration"` // seconds
+	PerQuestion   int `json:"per_question"`   // seconds
+	Bonus         int `json:"bonus"`          // seconds
+	Penalty       int `json:"penalty"`        // seconds
 
 	// Life settings
-	LifeMode LifeMode // default NoLives
-	Lives    int
+	LifeMode LifeMode `json:"life_mode"` // default NoLives
+	Lives    int      `json:"lives"`
 
 	// Progression settings
-	Progression Progression // default Fixed
+	Progression Progression `json:"progression"` // default Fixed
+	Streak      int         `json:"streak"`      // correct in a row to scale up, 0 = default
+	Window      int         `json:"window"`      // answers to measure accuracy over, 0 = default
+	Accuracy    float32     `json:"accuracy"`    // accuracy over the window to scale up
 
 	// Question filters
-	Categories    CategorySet     // default all
-	Types         QuestionTypeSet // default all
-	Randomize     bool
-	QuestionCount QuestionCount // 0 = all
+	Categories     CategorySet     `json:"categories,omitempty"` // default all
+	Types          QuestionTypeSet `json:"types"`                // default all
+	Randomize      bool            `json:"randomize"`
+	ShuffleOptions bool            `json:"shuffle_options"`
+	QuestionCount  QuestionCount   `json:"question_count"` // 0 = all
 }
 
-func newCustomMode() *Custom {
+func NewCustomMode() *Custom {
 	return &Custom{
 		Control:       Unlimited,
 		LifeMode:      NoLives,
 		Progression:   Fixed,
+		Types:         QuestionTypeSet{Choice, MultipleChoice, TextEntry, Bool, ShortAnswer, Ordering},
 		Randomize:     false,
 		QuestionCount: 0,
 	}
@@ -193,14 +268,20 @@ func (gm *Custom) Format(difficulty Difficulty) Format {
 				Lives: gm.Lives,
 			},
 		),
-		Progression: ProgressionRules{
-			Mode:       gm.Progression,
-			Difficulty: difficulty,
-		},
+		Progression: BuildProgressionRules(
+			gm.Progression,
+			difficulty,
+			ProgressionOptions{
+				Streak:   gm.Streak,
+				Window:   gm.Window,
+				Accuracy: gm.Accuracy,
+			},
+		),
 		Question: QuestionRules{
 			CategoryFilter: gm.Categories,
 			Types:          gm.Types,
 			Randomize:      gm.Randomize,
+			ShuffleOptions: gm.ShuffleOptions,
 			Count:          gm.QuestionCount,
 		},
 		Description: "Custom practice mode",
@@ -0,0 +1,69 @@
+package engine
+
+// OrderPolicy is how an ordering answer earns credit
+type OrderPolicy int
+
+const (
+	KendallTau OrderPolicy = iota // share of item pairs in the right relative order
+	ByPosition                    // share of items in exactly the right place
+)
+
+func (p OrderPolicy) String() string {
+	switch p {
+	case KendallTau:
+		return "kendall tau"
+	case ByPosition:
+		return "by position"
+	default:
+		return ""
+	}
+}
+
+// GradeOrder scores order, the pack indices of the items in the
+// order they were put in, against the correct order 0, 1, 2, ...
+// It returns the credit earned, 0-1
+func GradeOrder(order []int, n int, policy OrderPolicy) float64 {
+	if n == 0 || len(order) != n {
+		return 0
+	}
+
+	switch policy {
+	case ByPosition:
+		right := 0
+		for i, item := range order {
+			if item == i {
+				right++
+			}
+		}
+		return float64(right) / float64(n)
+
+	default:
+		// One item is trivially in order
+		if n == 1 {
+			return 1
+		}
+
+		concordant := 0
+		for i := range order {
+			for j := i + 1; j < len(order); j++ {
+				if order[i] < order[j] {
+					concordant++
+				}
+			}
+		}
+		return float64(concordant) / float64(n*(n-1)/2)
+	}
+}
+
+// inOrder reports whether order is exactly the correct order
+func inOrder(order []int, n int) bool {
+	if len(order) != n {
+		return false
+	}
+	for i, item := range order {
+		if item != i {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGradeOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		order  []int
+		n      int
+		policy OrderPolicy
+		want   float64
+	}{
+		{"kendall in order", []int{0, 1, 2, 3}, 4, KendallTau, 1},
+		{"kendall reversed", []int{3, 2, 1, 0}, 4, KendallTau, 0},
+		{"kendall one swap", []int{1, 0, 2, 3}, 4, KendallTau, 5.0 / 6},
+		{"kendall last moved first", []int{3, 0, 1, 2}, 4, KendallTau, 3.0 / 6},
+		{"kendall one item", []int{0}, 1, KendallTau, 1},
+		{"position in order", []int{0, 1, 2, 3}, 4, ByPosition, 1},
+		{"position one swap", []int{1, 0, 2, 3}, 4, ByPosition, 0.5},
+		{"position last moved first", []int{3, 0, 1, 2}, 4, ByPosition, 0},
+		{"missing items", []int{0, 1}, 4, KendallTau, 0},
+		{"nothing to order", nil, 0, KendallTau, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GradeOrder(tt.order, tt.n, tt.policy); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("GradeOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
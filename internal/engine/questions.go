@@ -4,19 +4,39 @@ type Questions []Question
 
 type Question interface {
 	Type() QuestionType
+	GetID() string
 	GetPrompt() string
 	GetAnswer() Answer
+	GetNotes() Notes
+	GetDifficulty() Difficulty
+}
+
+// Notes is the optional study material shown around a question
+type Notes struct {
+	Explanation string
+	References  []string // URLs or doc titles
+	Hint        string
+}
+
+func (n Notes) IsEmpty() bool {
+	return n.Explanation == "" && len(n.References) == 0 && n.Hint == ""
 }
 
 type BaseQuestion struct {
-	ID     string
-	Prompt string
+	ID         string
+	Prompt     string
+	Notes      Notes
+	Difficulty Difficulty
 }
 
 func (q BaseQuestion) GetPrompt() string { return q.Prompt }
 
 func (q BaseQuestion) GetID() string { return q.ID }
 
+func (q BaseQuestion) GetNotes() Notes { return q.Notes }
+
+func (q BaseQuestion) GetDifficulty() Difficulty { return q.Difficulty }
+
 type ChoiceQuestion struct {
 	BaseQuestion
 	Options []string
@@ -54,8 +74,8 @@ func (q BoolQuestion) GetAnswer() Answer {
 
 type TextEntryQuestion struct {
 	BaseQuestion
-	ExpectedAnswer string   // Idead answer for AI comparison
-	Keywords       []string // Key concepts that should be present
+	ExpectedAnswer string    // Idead answer for AI comparison
+	Keywords       []Keyword // Key concepts that should be present
 }
 
 func (q TextEntryQuestion) Type() QuestionType { return TextEntry }
@@ -63,3 +83,36 @@ func (q TextEntryQuestion) Type() QuestionType { return TextEntry }
 func (q TextEntryQuestion) GetAnswer() Answer {
 	return TextEntryAnswer{Text: q.ExpectedAnswer}
 }
+
+// ShortAnswerQuestion expects a word, a name or a number typed in, like
+// a port or a keyword. Any one of the ways to accept it is enough
+type ShortAnswerQuestion struct {
+	BaseQuestion
+	Accepted      []string // exact answers, compared with spacing normalized
+	CaseSensitive bool
+	Patterns      []string // regular expressions the whole answer has to match
+	Number        *float64 // a numeric answer, anything within Tolerance counts
+	Tolerance     float64
+}
+
+func (q ShortAnswerQuestion) Type() QuestionType { return ShortAnswer }
+
+func (q ShortAnswerQuestion) GetAnswer() Answer {
+	return ShortAnswerAnswer{Text: q.display()}
+}
+
+// OrderingQuestion asks for Items to be put back in order
+type OrderingQuestion struct {
+	BaseQuestion
+	Items []string // in the correct order
+}
+
+func (q OrderingQuestion) Type() QuestionType { return Ordering }
+
+func (q OrderingQuestion) GetAnswer() Answer {
+	order := make([]int, len(q.Items))
+	for i := range order {
+		order[i] = i
+	}
+	return OrderingAnswer{Order: order}
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Answers and grade results are interfaces, so on their own JSON
+// can't tell which concrete type to decode into. The records below
+// wrap them with a type tag to keep the encoding stable on disk:
+//
+//	{"type": "choice", "data": {"selected": 2}}
+
+var ErrUnknownRecord = errors.New("unknown record type")
+
+type taggedRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type AnswerRecord struct {
+	Answer Answer
+}
+
+func (r AnswerRecord) MarshalJSON() ([]byte, error) {
+	if r.Answer == nil {
+		return []byte("null"), nil
+	}
+
+	data, err := json.Marshal(r.Answer)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(taggedRecord{
+		Type: answerTag(r.Answer),
+		Data: data,
+	})
+}
+
+func (r *AnswerRecord) UnmarshalJSON(data []byte) error {
+	var tagged *taggedRecord
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	if tagged == nil {
+		r.Answer = nil
+		return nil
+	}
+
+	var err error
+	switch tagged.Type {
+	case "choice":
+		var a ChoiceAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	case "multiple_choice":
+		var a MultipleChoiceAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	case "bool":
+		var a BoolAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	case "text_entry":
+		var a TextEntryAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	case "short_answer":
+		var a ShortAnswerAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	case "ordering":
+		var a OrderingAnswer
+		err = json.Unmarshal(tagged.Data, &a)
+		r.Answer = a
+	default:
+		return fmt.Errorf("%w: answer %q", ErrUnknownRecord, tagged.Type)
+	}
+
+	return err
+}
+
+func answerTag(a Answer) string {
+	switch a.(type) {
+	case ChoiceAnswer:
+		return "choice"
+	case MultipleChoiceAnswer:
+		return "multiple_choice"
+	case BoolAnswer:
+		return "bool"
+	case TextEntryAnswer:
+		return "text_entry"
+	case ShortAnswerAnswer:
+		return "short_answer"
+	case OrderingAnswer:
+		return "ordering"
+	default:
+		return ""
+	}
+}
+
+type GradeRecord struct {
+	Result GradeResult
+}
+
+func (r GradeRecord) MarshalJSON() ([]byte, error) {
+	if r.Result == nil {
+		return []byte("null"), nil
+	}
+
+	data, err := json.Marshal(r.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(taggedRecord{
+		Type: gradeTag(r.Result),
+		Data: data,
+	})
+}
+
+func (r *GradeRecord) UnmarshalJSON(data []byte) error {
+	var tagged *taggedRecord
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	if tagged == nil {
+		r.Result = nil
+		return nil
+	}
+
+	var err error
+	switch tagged.Type {
+	case "accuracy":
+		var res AccuracyResult
+		err = json.Unmarshal(tagged.Data, &res)
+		r.Result = res
+	case "binary":
+		var res BinaryResult
+		err = json.Unmarshal(tagged.Data, &res)
+		r.Result = res
+	case "score":
+		var res ScoreResult
+		err = json.Unmarshal(tagged.Data, &res)
+		r.Result = res
+	case "practice":
+		var res PracticeResult
+		err = json.Unmarshal(tagged.Data, &res)
+		r.Result = res
+	case "self":
+		var res SelfResult
+		err = json.Unmarshal(tagged.Data, &res)
+		r.Result = res
+	default:
+		return fmt.Errorf("%w: grade %q", ErrUnknownRecord, tagged.Type)
+	}
+
+	return err
+}
+
+func gradeTag(r GradeResult) string {
+	switch r.(type) {
+	case AccuracyResult:
+		return "accuracy"
+	case BinaryResult:
+		return "binary"
+	case ScoreResult:
+		return "score"
+	case PracticeResult:
+		return "practice"
+	case SelfResult:
+		return "self"
+	default:
+		return ""
+	}
+}
+
+// NewAnswerRecords wraps answers for encoding, nil answers stay nil
+func NewAnswerRecords(answers []Answer) []AnswerRecord {
+	records := make([]AnswerRecord, len(answers))
+	for i, a := range answers {
+		records[i] = AnswerRecord{Answer: a}
+	}
+	return records
+}
+
+func NewGradeRecords(results []GradeResult) []GradeRecord {
+	records := make([]GradeRecord, len(results))
+	for i, res := range results {
+		records[i] = GradeRecord{Result: res}
+	}
+	return records
+}
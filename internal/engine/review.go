@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"math"
+	"time"
+)
+
+// Review scheduling is based on SM-2: every answer gets a recall
+// quality from 0 (blackout) to 5 (perfect). Good recalls push the
+// next review further out, bad ones bring the card back tomorrow.
+
+type Quality int
+
+const (
+	QualityBlackout Quality = iota
+	QualityWrong
+	QualityHardWrong
+	QualityHard
+	QualityGood
+	QualityPerfect
+)
+
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+	day         = 24 * time.Hour
+)
+
+type ReviewCard struct {
+	Ease        float64   `json:"ease"`
+	Interval    int       `json:"interval"` // days
+	Repetitions int       `json:"repetitions"`
+	Due         time.Time `json:"due"`
+	LastGrade   Quality   `json:"last_grade"`
+	LastReview  time.Time `json:"last_review"`
+}
+
+func NewReviewCard() ReviewCard {
+	return ReviewCard{
+		Ease: defaultEase,
+	}
+}
+
+// IsDue reports whether the card should be reviewed at the given time
+func (c ReviewCard) IsDue(now time.Time) bool {
+	return !c.Due.After(now)
+}
+
+// Schedule returns the card after a review with the given quality
+func (c ReviewCard) Schedule(q Quality, now time.Time) ReviewCard {
+	q = min(max(q, QualityBlackout), QualityPerfect)
+
+	if q < QualityHard {
+		c.Repetitions = 0
+		c.Interval = 1
+	} else {
+		c.Repetitions++
+		switch c.Repetitions {
+		case 1:
+			c.Interval = 1
+		case 2:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.Ease))
+		}
+	}
+
+	miss := float64(QualityPerfect - q)
+	c.Ease += 0.1 - miss*(0.08+miss*0.02)
+	if c.Ease < minEase {
+		c.Ease = minEase
+	}
+
+	c.LastGrade = q
+	c.LastReview = now
+	c.Due = now.Add(time.Duration(c.Interval) * day)
+
+	return c
+}
+
+// QualityFromResult maps a grade result onto a recall quality. Partial
+// credit on a wrong answer never reaches a passing quality
+func QualityFromResult(r GradeResult) Quality {
+	switch res := r.(type) {
+	case AccuracyResult:
+		q := Quality(math.Round(float64(res.Accuracy) * float64(QualityPerfect)))
+		if !res.Correct {
+			q = min(q, QualityHardWrong)
+		}
+		return q
+	case SelfResult:
+		return res.Rating.Quality()
+	default:
+		if r.IsCorrect() {
+			return QualityGood
+		}
+		return QualityWrong
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestQualityFromResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result GradeResult
+		want   Quality
+	}{
+		{"perfect", AccuracyResult{Correct: true, Accuracy: 1}, QualityPerfect},
+		{"wrong with high credit", AccuracyResult{Correct: false, Accuracy: 0.95}, QualityHardWrong},
+		{"wrong with some credit", AccuracyResult{Correct: false, Accuracy: 0.5}, QualityHardWrong},
+		{"wrong with little credit", AccuracyResult{Correct: false, Accuracy: 0.2}, QualityWrong},
+		{"blank", AccuracyResult{Correct: false}, QualityBlackout},
+		{"binary right", BinaryResult{Correct: true}, QualityGood},
+		{"binary wrong", BinaryResult{Correct: false}, QualityWrong},
+		{"self rated", SelfResult{Rating: RatingEasy}, RatingEasy.Quality()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := QualityFromResult(tt.result); got != tt.want {
+				t.Errorf("QualityFromResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualityFromResultWrongNeverPasses(t *testing.T) {
+	for a := float32(0); a < 1; a += 0.05 {
+		res := AccuracyResult{Correct: false, Accuracy: a}
+		if q := QualityFromResult(res); q >= QualityHard {
+			t.Errorf("accuracy %.2f: wrong answer got passing quality %v", a, q)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// Each step reviews the card left by the one before
+	tests := []struct {
+		name        string
+		quality     Quality
+		interval    int
+		repetitions int
+		ease        float64
+	}{
+		{"first good", QualityGood, 1, 1, 2.5},
+		{"second good", QualityGood, 6, 2, 2.5},
+		{"third good", QualityGood, 15, 3, 2.5},
+		{"wrong resets", QualityWrong, 1, 0, 1.96},
+		{"perfect", QualityPerfect, 1, 1, 2.06},
+		{"hard", QualityHard, 6, 2, 1.92},
+		{"blackout", QualityBlackout, 1, 0, 1.3},
+		{"blackout floors ease", QualityBlackout, 1, 0, 1.3},
+		{"out of range counts as perfect", Quality(9), 1, 1, 1.4},
+	}
+
+	card := NewReviewCard()
+	for _, tt := range tests {
+		card = card.Schedule(tt.quality, now)
+
+		if card.Interval != tt.interval {
+			t.Errorf("%s: interval = %d, want %d", tt.name, card.Interval, tt.interval)
+		}
+		if card.Repetitions != tt.repetitions {
+			t.Errorf("%s: repetitions = %d, want %d", tt.name, card.Repetitions, tt.repetitions)
+		}
+		if math.Abs(card.Ease-tt.ease) > 1e-9 {
+			t.Errorf("%s: ease = %v, want %v", tt.name, card.Ease, tt.ease)
+		}
+		if want := now.Add(time.Duration(tt.interval) * day); !card.Due.Equal(want) {
+			t.Errorf("%s: due = %v, want %v", tt.name, card.Due, want)
+		}
+		if card.IsDue(now) || !card.IsDue(card.Due) {
+			t.Errorf("%s: due at the wrong time", tt.name)
+		}
+	}
+}
@@ -11,6 +11,7 @@ type TimeRules struct {
 	Bonus         time.Duration
 	Penalty       time.Duration
 	Navigation    Navigation
+	CanPause      bool
 }
 
 type TimeOptions struct {
@@ -18,6 +19,7 @@ type TimeOptions struct {
 	PerQuestion   time.Duration // 0 = unlimited
 	Bonus         time.Duration
 	Penalty       time.Duration
+	DisablePause  bool
 }
 
 type LifeRules struct {
@@ -32,13 +34,27 @@ type LifeOptions struct {
 
 type ProgressionRules struct {
 	Mode       Progression
-	Difficulty Difficulty
+	Difficulty Difficulty // Starting difficulty when scaling
+
+	// Scaling moves up a difficulty after Streak correct answers in a row,
+	// or once the accuracy over the last Window answers reaches Accuracy
+	Streak   int     // 0 = disabled
+	Window   int     // 0 = disabled
+	Accuracy float32 // 0-1
+}
+
+type ProgressionOptions struct {
+	Streak   int
+	Window   int
+	Accuracy float32
 }
 
 type QuestionRules struct {
 	CategoryFilter CategorySet
 	Types          QuestionTypeSet
 	Randomize      bool
+	ShuffleOptions bool // show choice options in a new order every session
+	SelfGrade      bool // the player rates their own text answers
 	Count          QuestionCount
 }
 
@@ -51,18 +67,21 @@ func BuildTimeRules(
 		return TimeRules{
 			Control:    Unlimited,
 			Navigation: Free,
+			CanPause:   !opt.DisablePause,
 		}
 	case TotalTime:
 		return TimeRules{
 			Control:       TotalTime,
 			TotalDuration: opt.TotalDuration,
 			Navigation:    Free,
+			CanPause:      !opt.DisablePause,
 		}
 	case PerQuestion:
 		return TimeRules{
 			Control:     PerQuestion,
 			PerQuestion: opt.PerQuestion,
 			Navigation:  Locked,
+			CanPause:    !opt.DisablePause,
 		}
 	case PerQuestionWithBonus:
 		return TimeRules{
@@ -71,12 +90,46 @@ func BuildTimeRules(
 			Bonus:       opt.Bonus,
 			Penalty:     opt.Penalty,
 			Navigation:  Locked,
+			CanPause:    !opt.DisablePause,
 		}
 	default:
 		panic("unknown time control")
 	}
 }
 
+func BuildProgressionRules(
+	mode Progression,
+	difficulty Difficulty,
+	opt ProgressionOptions,
+) ProgressionRules {
+	switch mode {
+	case Fixed:
+		return ProgressionRules{
+			Mode:       Fixed,
+			Difficulty: difficulty,
+		}
+	case Scaling:
+		rules := ProgressionRules{
+			Mode:       Scaling,
+			Difficulty: difficulty,
+			Streak:     opt.Streak,
+			Window:     opt.Window,
+			Accuracy:   opt.Accuracy,
+		}
+
+		// Sensible defaults when nothing was configured
+		if rules.Streak == 0 && rules.Window == 0 {
+			rules.Streak = 3
+			rules.Window = 5
+			rules.Accuracy = 0.8
+		}
+
+		return rules
+	default:
+		panic("unknown progression mode")
+	}
+}
+
 func BuildLifeRules(
 	mode LifeMode,
 	opt LifeOptions,
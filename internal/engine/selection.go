@@ -0,0 +1,94 @@
+package engine
+
+import "slices"
+
+// SelectionPolicy is how a multiple choice answer earns credit
+type SelectionPolicy int
+
+const (
+	AllOrNothing SelectionPolicy = iota // full credit for the exact set, nothing otherwise
+	Proportional                        // credit for every correct option picked, out of the correct options and wrong picks
+	Penalty                             // credit for every correct option picked, each wrong pick cancels one
+)
+
+func (p SelectionPolicy) String() string {
+	switch p {
+	case AllOrNothing:
+		return "all or nothing"
+	case Proportional:
+		return "proportional"
+	case Penalty:
+		return "penalty"
+	default:
+		return ""
+	}
+}
+
+// Picks are the options a multiple choice answer got wrong, by pack index
+type Picks struct {
+	Missed []int `json:"missed,omitempty"` // correct options left unselected
+	Wrong  []int `json:"wrong,omitempty"`  // selected options that aren't correct
+}
+
+// GetPicks lets the review point at the options that were off
+func (p Picks) GetPicks() Picks { return p }
+
+// IsEmpty is true when every option was picked right
+func (p Picks) IsEmpty() bool {
+	return len(p.Missed) == 0 && len(p.Wrong) == 0
+}
+
+// PickReporter is implemented by results that carry Picks
+type PickReporter interface {
+	GetPicks() Picks
+}
+
+// GradeSelection compares selected with expected as sets, so the order
+// options were picked in doesn't matter, and scores it under policy.
+// It returns the credit earned, 0-1, and the picks that were off
+func GradeSelection(expected, selected []int, policy SelectionPolicy) (float64, Picks) {
+	var picks Picks
+	hits := 0
+	for _, s := range unique(selected) {
+		if slices.Contains(expected, s) {
+			hits++
+		} else {
+			picks.Wrong = append(picks.Wrong, s)
+		}
+	}
+	for _, e := range unique(expected) {
+		if !slices.Contains(selected, e) {
+			picks.Missed = append(picks.Missed, e)
+		}
+	}
+
+	total := hits + len(picks.Missed)
+	if total == 0 {
+		// Nothing to pick, only a blank answer is right
+		if len(picks.Wrong) == 0 {
+			return 1, picks
+		}
+		return 0, picks
+	}
+
+	switch policy {
+	case Proportional:
+		// Wrong picks count against the answer, picking every option
+		// only earns the share of options that are correct
+		return float64(hits) / float64(total+len(picks.Wrong)), picks
+	case Penalty:
+		return max(0, float64(hits-len(picks.Wrong))/float64(total)), picks
+	default:
+		if picks.IsEmpty() {
+			return 1, picks
+		}
+		return 0, picks
+	}
+}
+
+// unique returns the sorted distinct values of s
+func unique(s []int) []int {
+	u := slices.Clone(s)
+	slices.Sort(u)
+	return slices.Compact(u)
+}
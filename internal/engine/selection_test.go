@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestGradeSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []int
+		selected []int
+		policy   SelectionPolicy
+		credit   float64
+		missed   []int
+		wrong    []int
+	}{
+		{"exact set", []int{0, 2}, []int{2, 0}, AllOrNothing, 1, nil, nil},
+		{"duplicates ignored", []int{0, 2}, []int{0, 0, 2}, AllOrNothing, 1, nil, nil},
+		{"all or nothing missed", []int{0, 2}, []int{0}, AllOrNothing, 0, []int{2}, nil},
+		{"proportional half", []int{0, 2}, []int{0}, Proportional, 0.5, []int{2}, nil},
+		{"proportional wrong pick", []int{0, 2}, []int{0, 2, 3}, Proportional, 2.0 / 3, nil, []int{3}},
+		{"proportional every option", []int{0, 2}, []int{0, 1, 2, 3}, Proportional, 0.5, nil, []int{1, 3}},
+		{"penalty cancels", []int{0, 2}, []int{0, 2, 3}, Penalty, 0.5, nil, []int{3}},
+		{"penalty floors at zero", []int{0}, []int{1, 2, 3}, Penalty, 0, []int{0}, []int{1, 2, 3}},
+		{"nothing to pick", nil, nil, Proportional, 1, nil, nil},
+		{"nothing to pick, picked", nil, []int{1}, Proportional, 0, nil, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credit, picks := GradeSelection(tt.expected, tt.selected, tt.policy)
+			if math.Abs(credit-tt.credit) > 1e-9 {
+				t.Errorf("credit = %v, want %v", credit, tt.credit)
+			}
+			if !slices.Equal(picks.Missed, tt.missed) {
+				t.Errorf("missed = %v, want %v", picks.Missed, tt.missed)
+			}
+			if !slices.Equal(picks.Wrong, tt.wrong) {
+				t.Errorf("wrong = %v, want %v", picks.Wrong, tt.wrong)
+			}
+		})
+	}
+}
+
+func TestGradeSelectionEveryOptionNeverFullCredit(t *testing.T) {
+	all := []int{0, 1, 2, 3}
+	for _, policy := range []SelectionPolicy{AllOrNothing, Proportional, Penalty} {
+		if credit, _ := GradeSelection([]int{1, 3}, all, policy); credit >= 1 {
+			t.Errorf("%s: selecting every option earned %v", policy, credit)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package engine
+
+// Rating is how well the player thinks they recalled an answer,
+// the four buttons of a flashcard
+type Rating int
+
+const (
+	RatingAgain Rating = iota + 1 // didn't know it
+	RatingHard                    // got there, with effort
+	RatingGood
+	RatingEasy
+)
+
+func (r Rating) String() string {
+	switch r {
+	case RatingAgain:
+		return "Again"
+	case RatingHard:
+		return "Hard"
+	case RatingGood:
+		return "Good"
+	case RatingEasy:
+		return "Easy"
+	default:
+		return ""
+	}
+}
+
+// Quality maps the rating onto the review scheduler's scale
+func (r Rating) Quality() Quality {
+	switch r {
+	case RatingHard:
+		return QualityHard
+	case RatingGood:
+		return QualityGood
+	case RatingEasy:
+		return QualityPerfect
+	default:
+		return QualityWrong
+	}
+}
+
+// Ratings in the order they're offered
+var Ratings = []Rating{RatingAgain, RatingHard, RatingGood, RatingEasy}
+
+// SelfGrader takes the player's own rating of a text answer as the grade.
+// Answers without a rating, and every other question type, go to Fallback
+type SelfGrader struct {
+	Fallback GradePolicy
+}
+
+func (g *SelfGrader) Grade(q Question, a Answer, at Attempt) GradeResult {
+	if textA, ok := a.(TextEntryAnswer); ok && textA.Rating != 0 {
+		return SelfResult{Rating: textA.Rating}
+	}
+
+	if g.Fallback == nil {
+		return (&AccuracyGrader{}).Grade(q, a, at)
+	}
+	return g.Fallback.Grade(q, a, at)
+}
+
+type SelfResult struct {
+	Rating Rating `json:"rating"`
+}
+
+// IsCorrect counts anything the player recalled, even with effort
+func (r SelfResult) IsCorrect() bool { return r.Rating >= RatingHard }
+
+func (r SelfResult) Type() GradeType { return SelfRated }
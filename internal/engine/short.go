@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// display is the answer shown as the correct one
+func (q ShortAnswerQuestion) display() string {
+	switch {
+	case len(q.Accepted) > 0:
+		return q.Accepted[0]
+	case q.Number != nil && q.Tolerance > 0:
+		return formatNumber(*q.Number) + " ± " + formatNumber(q.Tolerance)
+	case q.Number != nil:
+		return formatNumber(*q.Number)
+	case len(q.Patterns) > 0:
+		return "/" + q.Patterns[0] + "/"
+	default:
+		return ""
+	}
+}
+
+// Matches reports whether text is an accepted answer to q
+func (q ShortAnswerQuestion) Matches(text string) bool {
+	text = normalizeShort(text)
+	if text == "" {
+		return false
+	}
+
+	for _, accepted := range q.Accepted {
+		accepted = normalizeShort(accepted)
+		if accepted == text || (!q.CaseSensitive && strings.EqualFold(accepted, text)) {
+			return true
+		}
+	}
+
+	for _, pattern := range q.Patterns {
+		if re, err := CompileShortPattern(pattern, q.CaseSensitive); err == nil && re.MatchString(text) {
+			return true
+		}
+	}
+
+	if q.Number != nil {
+		if n, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", ""), 64); err == nil {
+			return math.Abs(n-*q.Number) <= q.Tolerance
+		}
+	}
+
+	return false
+}
+
+// CompileShortPattern compiles a short answer pattern so it
+// has to match the whole answer, not just part of it
+func CompileShortPattern(pattern string, caseSensitive bool) (*regexp.Regexp, error) {
+	flags := "(?i)"
+	if caseSensitive {
+		flags = ""
+	}
+	return regexp.Compile(flags + "^(?:" + pattern + ")$")
+}
+
+func normalizeShort(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// Permutation is the order options are shown in, Permutation[i]
+// is the pack index of the option displayed at position i
+type Permutation []int
+
+// NewPermutation shuffles the options of q, questions
+// without options get a nil permutation
+func NewPermutation(q Question) Permutation {
+	n := optionCount(q)
+	if n == 0 {
+		return nil
+	}
+	return rand.Perm(n)
+}
+
+func optionCount(q Question) int {
+	switch q := q.(type) {
+	case ChoiceQuestion:
+		return len(q.Options)
+	case MultipleChoiceQuestion:
+		return len(q.Options)
+	default:
+		return 0
+	}
+}
+
+// Question returns q with its options in display order and the correct
+// answer pointing at the displayed positions. A permutation that doesn't
+// fit the question leaves it as it is
+func (p Permutation) Question(q Question) Question {
+	if len(p) == 0 || len(p) != optionCount(q) {
+		return q
+	}
+
+	switch q := q.(type) {
+	case ChoiceQuestion:
+		q.Options = p.options(q.Options)
+		q.Correct = p.position(q.Correct)
+		return q
+	case MultipleChoiceQuestion:
+		q.Options = p.options(q.Options)
+		correct := make([]int, 0, len(q.Correct))
+		for _, c := range q.Correct {
+			correct = append(correct, p.position(c))
+		}
+		slices.Sort(correct)
+		q.Correct = correct
+		return q
+	default:
+		return q
+	}
+}
+
+// Answer maps an answer given on the displayed options back to pack
+// indices, so grading, review and history never see the shuffle
+func (p Permutation) Answer(a Answer) Answer {
+	if len(p) == 0 {
+		return a
+	}
+
+	switch a := a.(type) {
+	case ChoiceAnswer:
+		a.Selected = p.index(a.Selected)
+		return a
+	case MultipleChoiceAnswer:
+		selected := make([]int, 0, len(a.Selected))
+		for _, s := range a.Selected {
+			selected = append(selected, p.index(s))
+		}
+		slices.Sort(selected)
+		a.Selected = selected
+		return a
+	default:
+		return a
+	}
+}
+
+func (p Permutation) options(options []string) []string {
+	shuffled := make([]string, len(p))
+	for i, idx := range p {
+		shuffled[i] = options[idx]
+	}
+	return shuffled
+}
+
+// index is the pack index of the option displayed at position
+func (p Permutation) index(position int) int {
+	if position < 0 || position >= len(p) {
+		return position
+	}
+	return p[position]
+}
+
+// position is where the option at pack index is displayed
+func (p Permutation) position(index int) int {
+	if i := slices.Index(p, index); i >= 0 {
+		return i
+	}
+	return index
+}
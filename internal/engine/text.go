@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Keyword is a concept a text answer should mention
+type Keyword struct {
+	Term     string
+	Synonyms []string // other ways of saying Term that count the same
+	Weight   float32  // share of the credit, 0 counts as 1
+}
+
+func (k Keyword) weight() float32 {
+	if k.Weight <= 0 {
+		return 1
+	}
+	return k.Weight
+}
+
+// TextMatch is how a text answer covers the keywords of its question
+type TextMatch struct {
+	Found    []string // terms of the keywords that were mentioned
+	Missing  []string
+	Accuracy float32 // weighted share of keywords found, 0-1
+}
+
+// MatchText grades text against the keywords of q, offline. Both sides
+// are tokenized and stemmed, so "concurrently" still counts for
+// "concurrency", and small typos are forgiven. A question without
+// keywords is matched against its expected answer as a whole
+func MatchText(q TextEntryQuestion, text string) TextMatch {
+	keywords := q.Keywords
+	if len(keywords) == 0 && q.ExpectedAnswer != "" {
+		keywords = []Keyword{{Term: q.ExpectedAnswer}}
+	}
+
+	tokens := stems(text)
+
+	var match TextMatch
+	var found, total float32
+	for _, k := range keywords {
+		total += k.weight()
+		if mentions(tokens, k) {
+			found += k.weight()
+			match.Found = append(match.Found, k.Term)
+		} else {
+			match.Missing = append(match.Missing, k.Term)
+		}
+	}
+
+	if total > 0 {
+		match.Accuracy = found / total
+	}
+
+	return match
+}
+
+// mentions reports whether tokens contain the keyword or one of its
+// synonyms. Phrases also match written as one word, "wait group" as "WaitGroup"
+func mentions(tokens []string, k Keyword) bool {
+	for _, phrase := range append([]string{k.Term}, k.Synonyms...) {
+		if containsPhrase(tokens, stems(phrase)) {
+			return true
+		}
+		if joined := stems(strings.Join(strings.Fields(phrase), "")); len(joined) == 1 && containsPhrase(tokens, joined) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase looks for phrase as a run of consecutive tokens
+func containsPhrase(tokens, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+
+	for i := 0; i+len(phrase) <= len(tokens); i++ {
+		matched := true
+		for j, p := range phrase {
+			if !similar(tokens[i+j], p) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// similar allows more typos the longer the word is
+func similar(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	tolerance := 0
+	switch n := min(len(a), len(b)); {
+	case n > 8:
+		tolerance = 2
+	case n > 4:
+		tolerance = 1
+	}
+
+	return tolerance > 0 && levenshtein(a, b) <= tolerance
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// stems splits text into lowercase words and stems each of them
+func stems(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, w := range words {
+		words[i] = stem(w)
+	}
+	return words
+}
+
+// Derivational endings, longest first so "ation" wins over "ion"
+var suffixes = []string{
+	"ations", "ation", "ments", "ment", "ness", "ities", "ity",
+	"ency", "ence", "ancy", "ance", "ent", "ant", "ing", "ion",
+	"ive", "ize", "ise", "ers", "er", "ed", "al",
+}
+
+// stem is a light suffix stripper, not a full Porter stemmer. It only
+// has to bring the forms of a word people actually type to the same root
+func stem(w string) string {
+	const minStem = 3
+
+	cut := func(suffix string) bool {
+		if len(w)-len(suffix) >= minStem && strings.HasSuffix(w, suffix) {
+			w = w[:len(w)-len(suffix)]
+			return true
+		}
+		return false
+	}
+
+	if !cut("ly") {
+		switch {
+		case cut("ies"):
+			w += "y"
+		case strings.HasSuffix(w, "ss"):
+		case cut("es"), cut("s"):
+		}
+	}
+
+	for _, suffix := range suffixes {
+		if cut(suffix) {
+			break
+		}
+	}
+
+	cut("e")
+
+	// running -> runn -> run
+	if n := len(w); n > minStem && w[n-1] == w[n-2] && !strings.ContainsRune("aeiou", rune(w[n-1])) {
+		w = w[:n-1]
+	}
+
+	return w
+}
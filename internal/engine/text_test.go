@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"concurrency", "concurrently", true},
+		{"running", "run", true},
+		{"libraries", "library", true},
+		{"classes", "class", true},
+		{"implementing", "implemented", true},
+		{"scheduler", "scheduled", true},
+		{"channel", "channels", true},
+		{"class", "clas", false},
+		{"map", "mutex", false},
+	}
+
+	for _, tt := range tests {
+		if same := stem(tt.a) == stem(tt.b); same != tt.same {
+			t.Errorf("stem(%q) = %q, stem(%q) = %q, same = %v, want %v", tt.a, stem(tt.a), tt.b, stem(tt.b), same, tt.same)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"goroutine", "gorutine", 1},
+		{"flaw", "lawn", 2},
+		{"héllo", "hello", 1}, // runes, not bytes
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := levenshtein(tt.b, tt.a); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"map", "map", true},
+		{"map", "mop", false}, // short words have to be exact
+		{"mutex", "mutax", true},
+		{"mutex", "mitax", false},
+		{"goroutine", "gorotuine", true},
+		{"goroutine", "grtne", false},
+	}
+
+	for _, tt := range tests {
+		if got := similar(tt.a, tt.b); got != tt.want {
+			t.Errorf("similar(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMatchText(t *testing.T) {
+	goroutine := TextEntryQuestion{
+		ExpectedAnswer: "A lightweight thread managed by the Go runtime",
+		Keywords: []Keyword{
+			{Term: "lightweight", Synonyms: []string{"cheap"}},
+			{Term: "runtime", Weight: 2},
+			{Term: "wait group"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		q        TextEntryQuestion
+		text     string
+		accuracy float32
+		found    []string
+	}{
+		{"every keyword", goroutine, "A lightweight thread the runtime schedules, joined with a wait group", 1, []string{"lightweight", "runtime", "wait group"}},
+		{"synonym", goroutine, "It's cheap", 0.25, []string{"lightweight"}},
+		{"weighted", goroutine, "Managed by the runtime", 0.5, []string{"runtime"}},
+		{"phrase as one word", goroutine, "use a WaitGroup", 0.25, []string{"wait group"}},
+		{"typo", goroutine, "the runtiem does it", 0.5, []string{"runtime"}},
+		{"nothing", goroutine, "no idea", 0, nil},
+		{"expected answer without keywords", TextEntryQuestion{ExpectedAnswer: "garbage collection"}, "Garbage collected", 1, []string{"garbage collection"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := MatchText(tt.q, tt.text)
+			if math.Abs(float64(match.Accuracy-tt.accuracy)) > 1e-6 {
+				t.Errorf("accuracy = %v, want %v", match.Accuracy, tt.accuracy)
+			}
+			if !slices.Equal(match.Found, tt.found) {
+				t.Errorf("found = %v, want %v", match.Found, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package grading has the text backends an engine.ExternalGrader can talk to.
+//
+// Both speak the same JSON: an engine.GradeRequest goes out and an
+// engine.GradeResponse comes back. A command gets the request on stdin
+// and writes the response to stdout, a local server gets it as a POST body
+// and answers with it. For example a request
+//
+//	{"prompt": "What is a goroutine?", "expected": "A lightweight thread",
+//	 "keywords": [{"term": "lightweight", "weight": 1}], "answer": "a cheap thread"}
+//
+// could be answered with
+//
+//	{"accuracy": 0.8, "feedback": "Close, mention that the runtime schedules them"}
+package grading
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+var ErrNoBackend = errors.New("no grader backend configured")
+
+// maxResponse caps how much of a backend's answer is read
+const maxResponse = 1 << 20
+
+// Command runs a program for every answer
+type Command struct {
+	Path string
+	Args []string
+}
+
+func (c *Command) GradeText(ctx context.Context, req engine.GradeRequest) (engine.GradeResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return engine.GradeResponse{}, fmt.Errorf("failed to marshal grade request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.Path, c.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return engine.GradeResponse{}, fmt.Errorf("grader %s failed: %w: %s", c.Path, err, msg)
+		}
+		return engine.GradeResponse{}, fmt.Errorf("grader %s failed: %w", c.Path, err)
+	}
+
+	return decode(io.LimitReader(&stdout, maxResponse))
+}
+
+// HTTP posts every answer to a local server
+type HTTP struct {
+	URL    string
+	Client *http.Client // nil = http.DefaultClient
+}
+
+func (h *HTTP) GradeText(ctx context.Context, req engine.GradeRequest) (engine.GradeResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return engine.GradeResponse{}, fmt.Errorf("failed to marshal grade request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return engine.GradeResponse{}, fmt.Errorf("failed to build grade request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return engine.GradeResponse{}, fmt.Errorf("failed to reach grader: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return engine.GradeResponse{}, fmt.Errorf("grader answered %s", resp.Status)
+	}
+
+	return decode(io.LimitReader(resp.Body, maxResponse))
+}
+
+func decode(r io.Reader) (engine.GradeResponse, error) {
+	var resp engine.GradeResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return engine.GradeResponse{}, fmt.Errorf("failed to decode grade response: %w", err)
+	}
+
+	if resp.Accuracy < 0 || resp.Accuracy > 1 {
+		return engine.GradeResponse{}, fmt.Errorf("grade accuracy %v is outside 0-1", resp.Accuracy)
+	}
+
+	return resp, nil
+}
+
+// New picks the backend from the user's settings, a command
+// wins over a URL. Without either it returns ErrNoBackend
+func New(command []string, url string) (engine.TextBackend, error) {
+	switch {
+	case len(command) > 0:
+		return &Command{Path: command[0], Args: command[1:]}, nil
+	case url != "":
+		return &HTTP{URL: url}, nil
+	default:
+		return nil, ErrNoBackend
+	}
+}
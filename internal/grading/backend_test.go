@@ -0,0 +1,135 @@
+package grading
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+var goroutineQuestion = engine.TextEntryQuestion{
+	BaseQuestion:   engine.BaseQuestion{ID: "goroutine", Prompt: "What is a goroutine?"},
+	ExpectedAnswer: "A lightweight thread managed by the Go runtime",
+	Keywords:       []engine.Keyword{{Term: "lightweight"}, {Term: "runtime"}},
+}
+
+func TestExternalGraderHTTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+
+		correct  bool
+		accuracy float32
+		feedback string // a prefix of the feedback
+	}{
+		{
+			name: "graded by the backend",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var req engine.GradeRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Answer != "a lightweight thread" {
+					http.Error(w, "bad request", http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{"accuracy": 0.8, "feedback": "Mention the runtime"}`))
+			},
+			accuracy: 0.8,
+			feedback: "Mention the runtime",
+		},
+		{
+			name: "backend says correct",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"accuracy": 0.9, "feedback": "Good", "correct": true}`))
+			},
+			correct:  true,
+			accuracy: 0.9,
+			feedback: "Good",
+		},
+		{
+			name: "timeout falls back to keywords",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				// Long past the grader's timeout, short enough to close the server quickly
+				time.Sleep(500 * time.Millisecond)
+			},
+			accuracy: 0.5,
+			feedback: "Grader unavailable, graded by keywords",
+		},
+		{
+			name: "malformed response falls back",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"accuracy": `))
+			},
+			accuracy: 0.5,
+			feedback: "Grader unavailable, graded by keywords",
+		},
+		{
+			name: "accuracy out of range falls back",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"accuracy": 7}`))
+			},
+			accuracy: 0.5,
+			feedback: "Grader unavailable, graded by keywords",
+		},
+		{
+			name: "server error falls back",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "down", http.StatusInternalServerError)
+			},
+			accuracy: 0.5,
+			feedback: "Grader unavailable, graded by keywords",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			grader := &engine.ExternalGrader{
+				Backend:  &HTTP{URL: server.URL},
+				Timeout:  100 * time.Millisecond,
+				Fallback: &engine.AccuracyGrader{},
+			}
+
+			start := time.Now()
+			res, ok := grader.Grade(goroutineQuestion, engine.TextEntryAnswer{Text: "a lightweight thread"}, engine.Attempt{}).(engine.AccuracyResult)
+			if !ok {
+				t.Fatalf("Grade didn't return an AccuracyResult")
+			}
+			if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+				t.Errorf("Grade took %s, the timeout is 100ms", elapsed)
+			}
+
+			if res.Correct != tt.correct {
+				t.Errorf("correct = %v, want %v", res.Correct, tt.correct)
+			}
+			if res.Accuracy != tt.accuracy {
+				t.Errorf("accuracy = %v, want %v", res.Accuracy, tt.accuracy)
+			}
+			if !strings.HasPrefix(res.Feedback, tt.feedback) {
+				t.Errorf("feedback = %q, want it to start with %q", res.Feedback, tt.feedback)
+			}
+		})
+	}
+}
+
+func TestExternalGraderLeavesOtherQuestionsToFallback(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	grader := &engine.ExternalGrader{Backend: &HTTP{URL: server.URL}, Fallback: &engine.AccuracyGrader{}}
+	q := engine.BoolQuestion{BaseQuestion: engine.BaseQuestion{ID: "nil_map"}, Correct: true}
+
+	if res := grader.Grade(q, engine.BoolAnswer{Answer: true}, engine.Attempt{}); !res.IsCorrect() {
+		t.Errorf("bool answer graded wrong")
+	}
+	if called {
+		t.Errorf("backend was called for a bool question")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/cheezecakee/ace/internal/engine"
 )
@@ -12,6 +13,7 @@ const (
 	cachePath         = "cache/"
 	questionCachePath = cachePath + "question.json"
 	lookupCachePath   = cachePath + "lookup.json"
+	detailCachePath   = cachePath + "details.json"
 )
 
 type (
@@ -24,6 +26,18 @@ type (
 	Lookup        map[engine.Difficulty]RoleIndex
 )
 
+// QuestionDetails is what we know about a question
+// beyond what the engine needs to play it
+type QuestionDetails struct {
+	PackID     string            `json:"pack_id"`
+	Role       Role              `json:"role"`
+	Category   string            `json:"category"`
+	Difficulty engine.Difficulty `json:"difficulty"`
+	Type       Type              `json:"type"`
+}
+
+type DetailIndex map[string]QuestionDetails // QuestionID -> Details
+
 type Cache interface {
 	Load() error
 	Save() error
@@ -157,6 +171,14 @@ func (c Lookup) GetQuestionIDs(
 ) []string {
 	var ids []string
 
+	// Difficulty 0 means any difficulty
+	if difficulty == 0 {
+		for _, d := range c.difficulties() {
+			ids = append(ids, c.GetQuestionIDs(d, role, types)...)
+		}
+		return ids
+	}
+
 	// Check if difficulty and role exist
 	roleIdx, ok := c[difficulty]
 	if !ok {
@@ -186,6 +208,18 @@ func (c Lookup) GetAvailableRoles(
 ) []Role {
 	var roles []Role
 
+	// Difficulty 0 means any difficulty
+	if difficulty == 0 {
+		for _, d := range c.difficulties() {
+			for _, role := range c.GetAvailableRoles(d, types) {
+				if !slices.Contains(roles, role) {
+					roles = append(roles, role)
+				}
+			}
+		}
+		return roles
+	}
+
 	// Check if difficulty exists in lookup
 	roleIdx, ok := c[difficulty]
 	if !ok {
@@ -212,3 +246,66 @@ func (c Lookup) GetAvailableRoles(
 
 	return roles
 }
+
+// difficulties returns the difficulties present in the lookup, easiest first
+func (c Lookup) difficulties() []engine.Difficulty {
+	difficulties := make([]engine.Difficulty, 0, len(c))
+	for d := range c {
+		difficulties = append(difficulties, d)
+	}
+	slices.Sort(difficulties)
+
+	return difficulties
+}
+
+func (c DetailIndex) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal detail index: %w", err)
+	}
+
+	err = os.WriteFile(detailCachePath, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write detail index: %w", err)
+	}
+
+	return nil
+}
+
+func (c *DetailIndex) Load() error {
+	data, err := os.ReadFile(detailCachePath)
+	if err != nil {
+		return fmt.Errorf("failed to read detail index: %w", err)
+	}
+
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal detail index: %w", err)
+	}
+
+	return nil
+}
+
+func (c *DetailIndex) Generate(m Metadata, packIDs []string) error {
+	// Clear existing index
+	*c = make(DetailIndex)
+
+	for _, packID := range packIDs {
+		pack, err := m.LoadPack(packID)
+		if err != nil {
+			return fmt.Errorf("failed to load pack %s: %w", packID, err)
+		}
+
+		for _, q := range pack.Questions {
+			(*c)[q.ID] = QuestionDetails{
+				PackID:     pack.Info.ID,
+				Role:       pack.Info.Role,
+				Category:   q.Category,
+				Difficulty: q.Difficulty,
+				Type:       q.Type,
+			}
+		}
+	}
+
+	return c.Save()
+}
@@ -0,0 +1,330 @@
+package pack
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Columns names the header of every column Import reads, headers
+// are matched ignoring case. Only the prompt column is required
+type Columns struct {
+	Category    string
+	Type        string
+	Difficulty  string
+	Prompt      string
+	Options     string
+	Answer      string
+	Keywords    string
+	Explanation string
+}
+
+func DefaultColumns() Columns {
+	return Columns{
+		Category:    "category",
+		Type:        "type",
+		Difficulty:  "difficulty",
+		Prompt:      "prompt",
+		Options:     "options",
+		Answer:      "answer",
+		Keywords:    "keywords",
+		Explanation: "explanation",
+	}
+}
+
+// Set points field at the column with header, for sheets
+// that call the prompt "Question" and the like
+func (c *Columns) Set(field, header string) error {
+	fields := map[string]*string{
+		"category":    &c.Category,
+		"type":        &c.Type,
+		"difficulty":  &c.Difficulty,
+		"prompt":      &c.Prompt,
+		"options":     &c.Options,
+		"answer":      &c.Answer,
+		"keywords":    &c.Keywords,
+		"explanation": &c.Explanation,
+	}
+
+	f, ok := fields[strings.ToLower(field)]
+	if !ok {
+		return fmt.Errorf("unknown column %q", field)
+	}
+	*f = header
+	return nil
+}
+
+// sheetRow is a row of the sheet by field, with the line it starts on
+type sheetRow struct {
+	line   int
+	values map[string]string
+}
+
+func (s sheetRow) get(field string) string {
+	return strings.TrimSpace(s.values[field])
+}
+
+// Import adds the questions of a CSV sheet with a header row to the pack,
+// comma is what separates the columns, '\t' for TSV. Cells with several
+// values, options and answers to multiple choice questions, split on "|".
+// Rows that can't become a question are skipped with a warning saying why,
+// the rest are still imported. Lines of the sheet are recorded so Verify
+// points at the row a question came from
+func (r *Raw) Import(data []byte, comma rune, cols Columns) (Report, error) {
+	var report Report
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = comma == '\t'
+
+	header, err := reader.Read()
+	if err != nil {
+		return report, sheetError(data, err)
+	}
+
+	index := make(map[string]int)
+	for field, name := range map[string]string{
+		"category":    cols.Category,
+		"type":        cols.Type,
+		"difficulty":  cols.Difficulty,
+		"prompt":      cols.Prompt,
+		"options":     cols.Options,
+		"answer":      cols.Answer,
+		"keywords":    cols.Keywords,
+		"explanation": cols.Explanation,
+	} {
+		if i := slices.IndexFunc(header, func(h string) bool {
+			return strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), name)
+		}); i >= 0 {
+			index[field] = i
+		}
+	}
+	if _, ok := index["prompt"]; !ok {
+		return report, fmt.Errorf("%w: the sheet has no %q column", ErrMissingFields, cols.Prompt)
+	}
+
+	if r.Categories == nil {
+		r.Categories = make(map[string]RawCategory)
+	}
+	if r.positions == nil {
+		r.positions = make(map[string]Position)
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, sheetError(data, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := sheetRow{line: line, values: make(map[string]string)}
+		for field, i := range index {
+			if i < len(record) {
+				row.values[field] = record[i]
+			}
+		}
+
+		if slices.IndexFunc(record, func(v string) bool { return strings.TrimSpace(v) != "" }) < 0 {
+			continue
+		}
+
+		if issue, ok := r.importRow(row); !ok {
+			report.Warnings = append(report.Warnings, issue)
+		}
+	}
+
+	return report, nil
+}
+
+// importRow adds the question in row, or says why it can't
+func (r *Raw) importRow(row sheetRow) (Issue, bool) {
+	fail := func(kind IssueKind, format string, args ...any) (Issue, bool) {
+		issue := NewWarning(kind, fmt.Sprintf(format, args...), fmt.Sprintf("row %d", row.line), "")
+		issue.Pos = Position{Line: row.line, Col: 1}
+		return issue, false
+	}
+
+	category := slug(row.get("category"))
+	if category == "" {
+		category = "general"
+	}
+
+	options := splitCell(row.get("options"), "|")
+	answer := row.get("answer")
+
+	list, ok := parseSheetType(row.get("type"))
+	if !ok {
+		return fail(IssueInvalidFormat, "Unknown question type %q", row.get("type"))
+	}
+	if list == "" {
+		list = inferSheetType(options, answer)
+	}
+
+	notes := RawNotes{Explanation: row.get("explanation")}
+	difficulty := strings.ToLower(row.get("difficulty"))
+	prompt := row.get("prompt")
+
+	c := r.Categories[category]
+	var n int
+
+	switch list {
+	case "choice", "multiple_choice":
+		var picks []int
+		for _, a := range splitCell(answer, "|") {
+			i, ok := optionIndex(options, a)
+			if !ok {
+				return fail(IssueInvalidAnswer, "Answer %q isn't one of the options", a)
+			}
+			picks = append(picks, i)
+		}
+
+		if list == "choice" {
+			if len(picks) != 1 {
+				return fail(IssueInvalidAnswer, "A choice question needs exactly one answer, got %d", len(picks))
+			}
+			n = len(c.Choice)
+			c.Choice = append(c.Choice, RawChoiceQuestion{Difficulty: difficulty, Prompt: prompt, Options: options, Answer: picks[0], RawNotes: notes})
+		} else {
+			n = len(c.MultipleChoice)
+			c.MultipleChoice = append(c.MultipleChoice, RawMultiQuestion{Difficulty: difficulty, Prompt: prompt, Options: options, Answer: picks, RawNotes: notes})
+		}
+
+	case "bool":
+		value, ok := parseSheetBool(answer)
+		if !ok {
+			return fail(IssueInvalidAnswer, "Answer %q isn't true or false", answer)
+		}
+		n = len(c.Bool)
+		c.Bool = append(c.Bool, RawBoolQuestion{Difficulty: difficulty, Prompt: prompt, Answer: value, RawNotes: notes})
+
+	case "text_entry":
+		sep := "|"
+		if !strings.Contains(row.get("keywords"), "|") {
+			sep = ","
+		}
+		var keywords []RawKeyword
+		for _, k := range splitCell(row.get("keywords"), sep) {
+			keywords = append(keywords, RawKeyword{Term: k})
+		}
+		n = len(c.TextEntry)
+		c.TextEntry = append(c.TextEntry, RawTextQuestion{Difficulty: difficulty, Prompt: prompt, Expected: answer, Keywords: keywords, RawNotes: notes})
+
+	case "short_answer":
+		n = len(c.ShortAnswer)
+		c.ShortAnswer = append(c.ShortAnswer, RawShortQuestion{Difficulty: difficulty, Prompt: prompt, Accepted: splitCell(answer, "|"), RawNotes: notes})
+
+	case "ordering":
+		// The options are the items, in the right order
+		n = len(c.Ordering)
+		c.Ordering = append(c.Ordering, RawOrderQuestion{Difficulty: difficulty, Prompt: prompt, Items: options, RawNotes: notes})
+	}
+
+	r.Categories[category] = c
+	r.positions[questionPath(category, list, n)] = Position{Line: row.line, Col: 1}
+
+	return Issue{}, true
+}
+
+// parseSheetType reads the type column into the list the question goes in,
+// the names of the pack types and of the lists both work. An empty cell
+// gives an empty list, the type is inferred then
+func parseSheetType(s string) (string, bool) {
+	switch strings.ToLower(strings.NewReplacer(" ", "_", "-", "_", "/", "_").Replace(s)) {
+	case "":
+		return "", true
+	case "choice", "single", "single_choice":
+		return "choice", true
+	case "multi", "multiple", "multiple_choice":
+		return "multiple_choice", true
+	case "bool", "boolean", "true_false":
+		return "bool", true
+	case "text", "text_entry":
+		return "text_entry", true
+	case "short", "short_answer":
+		return "short_answer", true
+	case "order", "ordering":
+		return "ordering", true
+	default:
+		return "", false
+	}
+}
+
+// inferSheetType guesses the type of a row without one, options make
+// it a choice question and a true or false answer a bool one
+func inferSheetType(options []string, answer string) string {
+	switch {
+	case len(options) > 0 && len(splitCell(answer, "|")) > 1:
+		return "multiple_choice"
+	case len(options) > 0:
+		return "choice"
+	}
+
+	if _, ok := parseSheetBool(answer); ok {
+		return "bool"
+	}
+	return "text_entry"
+}
+
+func parseSheetBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "yes", "y":
+		return true, true
+	case "false", "f", "no", "n":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// optionIndex finds an answer among options by its text,
+// a letter (A is the first option) or a number starting at 1
+func optionIndex(options []string, answer string) (int, bool) {
+	if i := slices.IndexFunc(options, func(o string) bool { return strings.EqualFold(o, answer) }); i >= 0 {
+		return i, true
+	}
+
+	if len(answer) == 1 {
+		if c := answer[0] | 0x20; c >= 'a' && c <= 'z' && int(c-'a') < len(options) {
+			return int(c - 'a'), true
+		}
+	}
+
+	if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+		return n - 1, true
+	}
+
+	return 0, false
+}
+
+// splitCell splits a cell with several values, empty ones are dropped
+func splitCell(cell, sep string) []string {
+	var values []string
+	for v := range strings.SplitSeq(cell, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func sheetError(data []byte, err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: the sheet is empty", ErrMissingFields)
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		pos := Position{Line: parseErr.Line, Col: parseErr.Column}
+		return &SourceError{Err: ErrInvalidData, Pos: pos, Msg: parseErr.Err.Error(), Snippet: Snippet(data, pos)}
+	}
+	return err
+}
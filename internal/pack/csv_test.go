@@ -0,0 +1,128 @@
+package pack
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet string
+		comma rune
+
+		questions int
+		skipped   []int // lines of the rows skipped
+	}{
+		{
+			name: "every type",
+			sheet: `category,type,difficulty,prompt,options,answer,keywords
+networking,choice,junior,Which port does SSH use?,21|22|23,B,
+networking,multi,mid,Which are transport protocols?,TCP|UDP|HTTP,TCP|UDP,
+go,text,junior,What is a goroutine?,,A lightweight thread,"lightweight, runtime"
+go,,junior,A nil map can be read from.,,true,
+go,short,junior,Keyword to start a goroutine?,,go,
+go,order,mid,Order these,import|var|init,,
+`,
+			comma:     ',',
+			questions: 6,
+		},
+		{
+			name: "bad rows are skipped",
+			sheet: `prompt,options,answer,type
+Which port does SSH use?,21|22|23,3
+Which port does HTTP use?,80|443,8080
+Is this a question?,,maybe,bool
+What is it?,,,riddle
+
+Last one?,,yes
+`,
+			comma:     ',',
+			questions: 2,
+			skipped:   []int{3, 4, 5},
+		},
+		{
+			name:      "tsv",
+			sheet:     "Prompt\tAnswer\nWhat is a \"goroutine\"?\tA lightweight thread\n",
+			comma:     '\t',
+			questions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := &Raw{}
+			report, err := raw.Import([]byte(tt.sheet), tt.comma, DefaultColumns())
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if report.HasErrors() {
+				t.Errorf("Import() errors = %v, bad rows should only warn", report.Errors)
+			}
+
+			var skipped []int
+			for _, w := range report.Warnings {
+				skipped = append(skipped, w.Pos.Line)
+			}
+			if !slices.Equal(skipped, tt.skipped) {
+				t.Errorf("skipped rows on lines %v, want %v", skipped, tt.skipped)
+			}
+
+			if got := countQuestions(raw); got != tt.questions {
+				t.Errorf("imported %d questions, want %d", got, tt.questions)
+			}
+		})
+	}
+}
+
+func TestImportNeedsPrompt(t *testing.T) {
+	_, err := (&Raw{}).Import([]byte("question,answer\nWhat?,That\n"), ',', DefaultColumns())
+	if !errors.Is(err, ErrMissingFields) {
+		t.Errorf("Import() error = %v, want ErrMissingFields", err)
+	}
+
+	cols := DefaultColumns()
+	if err := cols.Set("prompt", "Question"); err != nil {
+		t.Fatal(err)
+	}
+	raw := &Raw{}
+	if _, err := raw.Import([]byte("question,answer\nWhat?,That\n"), ',', cols); err != nil {
+		t.Errorf("Import() with the prompt column mapped: %v", err)
+	}
+	if got := raw.Categories["general"].TextEntry; len(got) != 1 || got[0].Expected != "That" {
+		t.Errorf("imported %+v, want one text question expecting That", got)
+	}
+}
+
+func TestOptionIndex(t *testing.T) {
+	options := []string{"21", "22", "23"}
+	tests := []struct {
+		answer string
+		want   int
+		ok     bool
+	}{
+		{"22", 1, true},
+		{"b", 1, true},
+		{"C", 2, true},
+		{"1", 0, true},
+		{"d", 0, false},
+		{"4", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := optionIndex(options, tt.answer)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("optionIndex(%q) = %d, %v, want %d, %v", tt.answer, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func countQuestions(r *Raw) int {
+	n := 0
+	for _, c := range r.Categories {
+		n += len(c.Choice) + len(c.MultipleChoice) + len(c.Bool) + len(c.TextEntry) + len(c.ShortAnswer) + len(c.Ordering)
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package pack
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+// card is a question as the front and back of a flashcard
+type card struct {
+	front []string // prompt, then the options
+	back  []string // answer, then the explanation
+	tags  []string
+}
+
+// cards turns the pack's questions into flashcards, by category and ID
+func (p *Pack) cards() []card {
+	questions := slices.Clone(p.Questions)
+	slices.SortFunc(questions, func(a, b Question) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.ID, b.ID))
+	})
+
+	cards := make([]card, 0, len(questions))
+	for _, q := range questions {
+		eq := q.ToEngine()
+		if eq == nil {
+			continue
+		}
+
+		c := card{
+			front: []string{q.Prompt},
+			back:  []string{engine.DescribeAnswer(eq, eq.GetAnswer())},
+			tags:  []string{slug(q.Category), q.Difficulty.String()},
+		}
+
+		var options []string
+		switch a := q.Answer.(type) {
+		case ChoiceAnswer:
+			options = a.Options
+		case MultiAnswer:
+			options = a.Options
+		case OrderAnswer:
+			// Shuffling is up to the other tool, sorted doesn't give it away
+			options = slices.Sorted(slices.Values(a.Items))
+		}
+		for i, o := range options {
+			c.front = append(c.front, fmt.Sprintf("%c) %s", 'A'+i, o))
+		}
+
+		if q.Notes.Explanation != "" {
+			c.back = append(c.back, q.Notes.Explanation)
+		}
+
+		cards = append(cards, c)
+	}
+
+	return cards
+}
+
+// WriteAnki writes the pack as tab separated notes Anki imports as
+// Basic cards, front, back and tags with the category and difficulty
+func (p *Pack) WriteAnki(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "#separator:tab")
+	fmt.Fprintln(bw, "#html:true")
+	fmt.Fprintln(bw, "#tags column:3")
+
+	field := func(lines []string) string {
+		text := strings.ReplaceAll(strings.Join(lines, "\n"), "\t", "    ")
+		return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+	}
+
+	for _, c := range p.cards() {
+		fmt.Fprintf(bw, "%s\t%s\t%s\n", field(c.front), field(c.back), strings.Join(c.tags, " "))
+	}
+
+	return bw.Flush()
+}
+
+// WriteQuizlet writes the pack the way Quizlet's import expects it
+// by default, a term and its definition split by a tab on every line
+func (p *Pack) WriteQuizlet(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, c := range p.cards() {
+		term := flatten(strings.Join(c.front, " "))
+		definition := flatten(strings.Join(c.back, " - "))
+		fmt.Fprintf(bw, "%s\t%s\n", term, definition)
+	}
+
+	return bw.Flush()
+}
+
+// flatten puts s on a single line without tabs, so it fits in one field
+func flatten(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
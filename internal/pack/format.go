@@ -0,0 +1,1086 @@
+package pack
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the file format a pack is written in. Whatever the
+// format, a pack decodes into the same Raw and verifies the same way
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+	FormatTOML
+	FormatMarkdown // read only, see decodeMarkdown
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	case FormatTOML:
+		return "toml"
+	case FormatMarkdown:
+		return "markdown"
+	default:
+		return ""
+	}
+}
+
+// Writable reports whether packs can be saved in f
+func (f Format) Writable() bool {
+	return f != FormatMarkdown
+}
+
+// FormatOf picks the format of a pack file by its extension,
+// ok is false for extensions that aren't a pack format
+func FormatOf(path string) (format Format, ok bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, true
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".toml":
+		return FormatTOML, true
+	case ".md", ".markdown":
+		return FormatMarkdown, true
+	default:
+		return FormatJSON, false
+	}
+}
+
+// decodeSource turns a pack file into JSON so every format goes through
+// the same migration and decoding. locate finds where each JSON path is in
+// data, rename maps keys to the names the paths use
+func decodeSource(data []byte, format Format) (doc []byte, locate func(rename func(path, key string) string) map[string]Position, err error) {
+	switch format {
+	case FormatYAML:
+		return decodeYAML(data)
+	case FormatTOML:
+		return decodeTOML(data)
+	case FormatMarkdown:
+		return decodeMarkdown(data)
+	default:
+		locate = func(rename func(path, key string) string) map[string]Position {
+			return positions(data, index(data, rename))
+		}
+		return data, locate, nil
+	}
+}
+
+// Encode writes the pack in format
+func (r *Raw) Encode(format Format) ([]byte, error) {
+	return r.Rewrite(nil, format)
+}
+
+// Rewrite writes the pack in format as a change to orig, the file it was
+// read from, so only what changed differs. Every format keeps the file's
+// key order, comments and line endings, JSON its indentation and YAML its
+// string styles too
+func (r *Raw) Rewrite(orig []byte, format Format) ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSON:
+		data, err = encodeJSON(data, orig)
+	case FormatYAML:
+		data, err = encodeYAML(data, orig)
+	case FormatTOML:
+		data, err = encodeTOML(data, tomlOrder(orig), tomlComments(orig))
+	case FormatMarkdown:
+		return nil, fmt.Errorf("%w: %s", ErrNotWritable, format)
+	default:
+		return nil, fmt.Errorf("unknown pack format %d", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Contains(orig, []byte("\r\n")) {
+		data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	}
+	return data, nil
+}
+
+/** JSON **/
+
+// encodeJSON ends a marshaled pack with a newline. With orig the pack is
+// merged into it, JSON is YAML as far as mergeYAML goes, and written back
+// the way the file was: in its key order, with its indentation and with
+// whatever was on one line still on one line
+func encodeJSON(data, orig []byte) ([]byte, error) {
+	var old, node yaml.Node
+	if yaml.Unmarshal(orig, &old) != nil || len(old.Content) == 0 {
+		return append(data, '\n'), nil
+	}
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to convert pack: %w", err)
+	}
+
+	// What's new has no place in the file, see jsonWriter
+	unplace(&node)
+
+	w := &jsonWriter{lines: strings.Split(string(orig), "\n"), closes: jsonCloses(orig)}
+	w.write(mergeYAML(old.Content[0], node.Content[0]), "", "  ")
+	w.buf.WriteByte('\n')
+	return w.buf.Bytes(), nil
+}
+
+// jsonWriter writes a merged pack as JSON. Nodes from the file being
+// rewritten are indented like they were in it, new ones, without a line,
+// a step in from their parent
+type jsonWriter struct {
+	buf    bytes.Buffer
+	lines  []string          // of the file being rewritten
+	closes map[[2]int]string // see jsonCloses
+}
+
+// write writes node, indent is the indent of the line it starts on and step
+// how much further in the lines of a new node go
+func (w *jsonWriter) write(node *yaml.Node, indent, step string) {
+	open, close := "{", "}"
+	items := node.Content
+	if node.Kind == yaml.SequenceNode {
+		open, close = "[", "]"
+	}
+
+	switch {
+	case node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode:
+		if node.Tag == "!!str" || node.Style == yaml.DoubleQuotedStyle || node.Style == yaml.SingleQuotedStyle {
+			w.buf.WriteString(jsonString(node.Value))
+			return
+		}
+		w.buf.WriteString(node.Value)
+		return
+
+	case len(items) == 0:
+		w.buf.WriteString(open + close)
+		return
+
+	case oneLine(node):
+		sep := w.sep(node)
+		w.buf.WriteString(open)
+		for i := 0; i < len(items); i++ {
+			if i > 0 {
+				w.buf.WriteString(sep)
+			}
+			if node.Kind == yaml.MappingNode {
+				w.buf.WriteString(jsonString(items[i].Value) + ": ")
+				i++
+			}
+			w.write(items[i], indent, step)
+		}
+		w.buf.WriteString(close)
+		return
+	}
+
+	inner := w.inner(node, indent, step)
+	if strings.HasPrefix(inner, indent) && len(inner) > len(indent) {
+		step = inner[len(indent):]
+	}
+
+	w.buf.WriteString(open + "\n")
+	for i := 0; i < len(items); i++ {
+		w.buf.WriteString(inner)
+		if node.Kind == yaml.MappingNode {
+			w.buf.WriteString(jsonString(items[i].Value) + ": ")
+			i++
+		}
+		w.write(items[i], inner, step)
+		if i+1 < len(items) {
+			w.buf.WriteByte(',')
+		}
+		w.buf.WriteByte('\n')
+	}
+
+	if c, ok := w.closes[[2]int{node.Line, node.Column}]; ok {
+		indent = c
+	}
+	w.buf.WriteString(indent + close)
+}
+
+// inner is the indent of the items of node, the indent of the first
+// that started its own line in the file, or a step in from indent
+func (w *jsonWriter) inner(node *yaml.Node, indent, step string) string {
+	for i := 0; i < len(node.Content); i++ {
+		item := node.Content[i]
+		if node.Kind == yaml.MappingNode {
+			i++
+		}
+		if item.Line > node.Line && item.Line <= len(w.lines) {
+			line := w.lines[item.Line-1]
+			return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+	}
+	return indent + step
+}
+
+// sep is what's between the items of a list or object on one line,
+// a comma and a space unless the file has its first two closer
+func (w *jsonWriter) sep(node *yaml.Node) string {
+	step := 1
+	if node.Kind == yaml.MappingNode {
+		step = 2
+	}
+	if len(node.Content) <= step || node.Line == 0 || node.Line > len(w.lines) {
+		return ", "
+	}
+
+	first, next := node.Content[0], node.Content[step]
+	if step == 2 {
+		first = node.Content[1]
+	}
+	if first.Kind != yaml.ScalarNode || first.Line != node.Line || next.Line != node.Line {
+		return ", "
+	}
+
+	text := first.Value
+	if first.Tag == "!!str" || first.Style == yaml.DoubleQuotedStyle {
+		text = jsonString(text)
+	}
+	line := []rune(w.lines[node.Line-1])
+	from, to := first.Column-1+utf8.RuneCountInString(text), next.Column-1
+	if from < 0 || from > to || to > len(line) {
+		return ", "
+	}
+	if sep := string(line[from:to]); strings.Trim(sep, ", ") == "" && strings.Count(sep, ",") == 1 {
+		return sep
+	}
+	return ", "
+}
+
+// jsonCloses maps the line and column of each bracket of data that
+// opens an object or array to the indent of the line that closes it,
+// when the closing bracket starts its line
+func jsonCloses(data []byte) map[[2]int]string {
+	closes := make(map[[2]int]string)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var opens []int64
+	for {
+		before := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return closes
+		}
+
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			continue
+		}
+		switch delim {
+		case '{', '[':
+			// Token skips what's before the bracket, a comma or a colon and spaces
+			at := before + int64(bytes.IndexAny(data[before:], "{["))
+			opens = append(opens, at)
+		default:
+			if len(opens) == 0 {
+				return closes
+			}
+			at := opens[len(opens)-1]
+			opens = opens[:len(opens)-1]
+
+			end := dec.InputOffset() - 1
+			start := bytes.LastIndexByte(data[:end], '\n') + 1
+			if indent := data[start:end]; len(bytes.TrimLeft(indent, " \t")) == 0 {
+				line := bytes.Count(data[:at], []byte("\n")) + 1
+				col := utf8.RuneCount(data[bytes.LastIndexByte(data[:at], '\n')+1:at]) + 1
+				closes[[2]int{line, col}] = string(indent)
+			}
+		}
+	}
+}
+
+// unplace clears where the nodes under node are,
+// marking them as new to the file they're merged into
+func unplace(node *yaml.Node) {
+	node.Line, node.Column = 0, 0
+	for _, child := range node.Content {
+		unplace(child)
+	}
+}
+
+// jsonString quotes s, leaving <, > and & as they are
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// oneLine reports whether a list or object was written on one line, new
+// ones are when they're lists of plain values. Items added to one that was
+// stay on its line
+func oneLine(node *yaml.Node) bool {
+	if node.Line == 0 && node.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, item := range node.Content {
+		if item.Line != node.Line && item.Line != 0 {
+			return false
+		}
+		if item.Kind != yaml.ScalarNode && (node.Line == 0 || !oneLine(item)) {
+			return false
+		}
+	}
+	return true
+}
+
+/** YAML **/
+
+var yamlLine = regexp.MustCompile(`line (\d+)`)
+
+func decodeYAML(data []byte) ([]byte, func(func(path, key string) string) map[string]Position, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, yamlError(data, err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Pos: positionAt(data, 0), Msg: "the file is empty"}
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		pos := Position{Line: mapping.Line, Col: mapping.Column}
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: "a pack must be a mapping", Snippet: Snippet(data, pos)}
+	}
+
+	var value any
+	if err := mapping.Decode(&value); err != nil {
+		return nil, nil, yamlError(data, err)
+	}
+
+	doc, err := json.Marshal(value)
+	if err != nil {
+		// Only mappings with keys JSON can't have get here
+		pos := Position{Line: mapping.Line, Col: mapping.Column}
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: err.Error()}
+	}
+
+	locate := func(rename func(path, key string) string) map[string]Position {
+		pos := make(map[string]Position)
+		walkYAML(mapping, "", rename, pos)
+		return pos
+	}
+	return doc, locate, nil
+}
+
+func walkYAML(node *yaml.Node, path string, rename func(path, key string) string, pos map[string]Position) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if rename != nil {
+				key = rename(path, key)
+			}
+
+			child := joinPath(path, key)
+			if _, ok := pos[child]; !ok {
+				pos[child] = Position{Line: node.Content[i].Line, Col: node.Content[i].Column}
+			}
+			walkYAML(node.Content[i+1], child, rename, pos)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			pos[child] = Position{Line: item.Line, Col: item.Column}
+			walkYAML(item, child, rename, pos)
+		}
+	}
+}
+
+// yamlError locates a YAML error by the line in its message,
+// the parser doesn't say which column
+func yamlError(data []byte, err error) error {
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+
+	var pos Position
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		pos = Position{Line: line, Col: 1}
+
+		lines := strings.Split(string(data), "\n")
+		if line <= len(lines) {
+			text := lines[line-1]
+			pos.Col += len(text) - len(strings.TrimLeft(text, " \t"))
+		}
+		msg = strings.TrimPrefix(msg, m[0]+": ")
+	}
+
+	return &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: msg, Snippet: Snippet(data, pos)}
+}
+
+// encodeYAML rewrites a marshaled pack as block style YAML,
+// keeping the key order and writing multi-line strings as literals.
+// With orig, the pack is merged into it, see mergeYAML
+func encodeYAML(data, orig []byte) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to convert pack to YAML: %w", err)
+	}
+	blockStyle(&root)
+
+	// A file that doesn't parse has nothing worth keeping
+	var old yaml.Node
+	if yaml.Unmarshal(orig, &old) == nil && len(old.Content) > 0 && len(root.Content) > 0 {
+		old.Content[0] = mergeYAML(old.Content[0], root.Content[0])
+		root = old
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return nil, fmt.Errorf("failed to encode pack as YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode pack as YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && strings.Contains(node.Value, "\n") {
+		node.Style = yaml.LiteralStyle
+	}
+
+	// Unset lists are left out rather than written as null
+	if node.Kind == yaml.MappingNode {
+		content := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i+1].Tag == "!!null" {
+				continue
+			}
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+	}
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// mergeYAML updates old, a node of the file being rewritten, to the value
+// of node. Whatever both have keeps its comments, style and place in old,
+// new keys go after the key they follow in node and below the comment
+// the mapping starts with
+func mergeYAML(old, node *yaml.Node) *yaml.Node {
+	if old.Kind != node.Kind {
+		node.HeadComment, node.LineComment, node.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		return node
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		at := make(map[string]int, len(old.Content)/2) // key -> index in old
+		for i := 0; i+1 < len(old.Content); i += 2 {
+			at[old.Content[i].Value] = i
+		}
+
+		type pair struct {
+			key, value *yaml.Node
+			rank       int
+		}
+		pairs := make([]pair, 0, len(node.Content)/2)
+		rank := -1
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if j, ok := at[key.Value]; ok {
+				key, value, rank = old.Content[j], mergeYAML(old.Content[j+1], value), j
+			}
+			pairs = append(pairs, pair{key, value, rank})
+		}
+		slices.SortStableFunc(pairs, func(a, b pair) int { return cmp.Compare(a.rank, b.rank) })
+
+		// A comment above the first key is above the mapping,
+		// it stays there when a new key goes first
+		if len(old.Content) > 0 && len(pairs) > 0 && pairs[0].rank < 0 {
+			first := old.Content[0]
+			pairs[0].key.HeadComment, first.HeadComment = first.HeadComment, ""
+		}
+
+		old.Content = old.Content[:0]
+		for _, p := range pairs {
+			old.Content = append(old.Content, p.key, p.value)
+		}
+
+	case yaml.SequenceNode:
+		for i := range min(len(old.Content), len(node.Content)) {
+			node.Content[i] = mergeYAML(old.Content[i], node.Content[i])
+		}
+		old.Content = node.Content
+
+	case yaml.ScalarNode:
+		if old.Value != node.Value || old.Tag != node.Tag {
+			// A literal can't hold a single line, a plain string can't hold
+			// more, and a value that changes type, a null that becomes a
+			// string, takes the style of its new type
+			literal := old.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+			if old.Tag != node.Tag || literal != strings.Contains(node.Value, "\n") {
+				old.Style = node.Style
+			}
+			old.Value, old.Tag = node.Value, node.Tag
+		}
+	}
+
+	return old
+}
+
+/** TOML **/
+
+func decodeTOML(data []byte) ([]byte, func(func(path, key string) string) map[string]Position, error) {
+	var value map[string]any
+	if _, err := toml.Decode(string(data), &value); err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			pos := Position{Line: parseErr.Position.Line, Col: parseErr.Position.Col}
+			return nil, nil, &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: parseErr.Message, Snippet: Snippet(data, pos)}
+		}
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Msg: err.Error()}
+	}
+
+	doc, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Msg: err.Error()}
+	}
+
+	locate := func(rename func(path, key string) string) map[string]Position {
+		return tomlPositions(data, rename)
+	}
+	return doc, locate, nil
+}
+
+// tomlPositions finds where tables and keys are by reading the file line by
+// line. Values inside inline tables and arrays share the line of their key
+func tomlPositions(data []byte, rename func(path, key string) string) map[string]Position {
+	pos := make(map[string]Position)
+	scanTOML(data, rename, func(path string, _ int, at Position) {
+		if _, ok := pos[path]; !ok && path != "" {
+			pos[path] = at
+		}
+	})
+	return pos
+}
+
+// scanTOML calls visit with the JSON path of every table header and key of
+// data, and the index and position of the line it's on. Comment lines are
+// visited with an empty path, lines inside multi-line values aren't visited
+func scanTOML(data []byte, rename func(path, key string) string, visit func(path string, n int, at Position)) {
+	arrays := make(map[string]int) // array of tables -> index of its last table
+
+	// Builds the path of dotted keys under table, the last
+	// table of an array of tables is the one keys go into
+	resolve := func(table string, keys []string) string {
+		path := table
+		for _, key := range keys {
+			if rename != nil {
+				key = rename(path, key)
+			}
+			path = joinPath(path, key)
+			if i, ok := arrays[path]; ok {
+				path = fmt.Sprintf("%s[%d]", path, i)
+			}
+		}
+		return path
+	}
+
+	table := ""
+	depth := 0      // open brackets of a multi-line array
+	multiline := "" // delimiter of an open multi-line string
+	for n, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		at := Position{Line: n + 1, Col: indent + 1}
+
+		if multiline != "" {
+			if strings.Contains(trimmed, multiline) {
+				multiline = ""
+			}
+			continue
+		}
+		if depth > 0 {
+			depth += strings.Count(trimmed, "[") - strings.Count(trimmed, "]")
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			visit("", n, at)
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "[["):
+			end := strings.Index(trimmed, "]]")
+			if end < 0 {
+				continue
+			}
+			keys := splitTOMLKey(trimmed[2:end])
+			parent := resolve("", keys[:len(keys)-1])
+			name := keys[len(keys)-1]
+			if rename != nil {
+				name = rename(parent, name)
+			}
+
+			array := joinPath(parent, name)
+			i, seen := arrays[array]
+			if seen {
+				i++
+			}
+			arrays[array] = i
+
+			table = fmt.Sprintf("%s[%d]", array, i)
+			visit(table, n, at)
+
+		case strings.HasPrefix(trimmed, "["):
+			end := strings.Index(trimmed, "]")
+			if end < 0 {
+				continue
+			}
+			table = resolve("", splitTOMLKey(trimmed[1:end]))
+			visit(table, n, at)
+
+		default:
+			key, value, ok := strings.Cut(trimmed, "=")
+			if !ok {
+				continue
+			}
+			visit(resolve(table, splitTOMLKey(key)), n, at)
+
+			value = strings.TrimSpace(value)
+			for _, delim := range []string{`"""`, `'''`} {
+				if strings.HasPrefix(value, delim) && !strings.Contains(value[len(delim):], delim) {
+					multiline = delim
+				}
+			}
+			if strings.HasPrefix(value, "[") {
+				depth = strings.Count(value, "[") - strings.Count(value, "]")
+			}
+		}
+	}
+}
+
+// tomlComment is what a TOML file has to say about a key or table,
+// the lines of comments above it and the comment at the end of its line
+type tomlComment struct {
+	head, line string
+}
+
+// tomlComments finds the comments of a TOML file by the JSON path of the
+// key or table they're about, comments after the last key are under ""
+func tomlComments(data []byte) map[string]tomlComment {
+	if len(data) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	comments := make(map[string]tomlComment)
+	first := -1 // line of the first comment not yet given to a key
+
+	scanTOML(data, nil, func(path string, n int, _ Position) {
+		if path == "" {
+			if first < 0 {
+				first = n
+			}
+			return
+		}
+
+		c := comments[path]
+		if first >= 0 {
+			c.head = strings.Join(lines[first:n], "\n") + "\n"
+			first = -1
+		}
+		c.line = tomlLineComment(lines[n])
+		if c != (tomlComment{}) {
+			comments[path] = c
+		}
+	})
+
+	if first >= 0 {
+		comments[""] = tomlComment{head: strings.TrimRight(strings.Join(lines[first:], "\n"), "\n") + "\n"}
+	}
+	return comments
+}
+
+// tomlLineComment finds the comment at the end of a line, a # outside
+// strings. Lines that start a multi-line string have none
+func tomlLineComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == 0 && (strings.HasPrefix(line[i:], `"""`) || strings.HasPrefix(line[i:], `'''`)):
+			delim := line[i : i+3]
+			end := strings.Index(line[i+3:], delim)
+			if end < 0 {
+				return ""
+			}
+			i += 3 + end + 2
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && c == '#':
+			return strings.TrimSpace(line[i:])
+		}
+	}
+	return ""
+}
+
+// splitTOMLKey splits a dotted key, quoted parts can have dots in them
+func splitTOMLKey(key string) []string {
+	var (
+		keys  []string
+		part  strings.Builder
+		quote rune
+	)
+
+	for _, r := range strings.TrimSpace(key) {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			part.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '.':
+			keys = append(keys, strings.TrimSpace(part.String()))
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+
+	return append(keys, strings.TrimSpace(part.String()))
+}
+
+// tomlOrder ranks the keys of a TOML file by where they are in it, nil
+// when there's no file. Keys are JSON paths, only arrays of tables have
+// indexes, the keys of inline tables in a list share theirs
+func tomlOrder(data []byte) map[string]int {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var value map[string]any
+	md, err := toml.Decode(string(data), &value)
+	if err != nil {
+		return nil
+	}
+
+	order := make(map[string]int)
+	arrays := make(map[string]int) // array of tables -> index of its last table
+	for i, key := range md.Keys() {
+		// Every table of an array of tables lists the array again,
+		// arrays are kept by their path without indexes
+		plain := ""
+		for _, k := range key {
+			plain = joinPath(plain, k)
+		}
+		if md.Type(key...) == "ArrayHash" {
+			if n, ok := arrays[plain]; ok {
+				arrays[plain] = n + 1
+			} else {
+				arrays[plain] = 0
+			}
+		}
+
+		path, prefix := "", ""
+		for j := range key {
+			path, prefix = joinPath(path, key[j]), joinPath(prefix, key[j])
+			if n, ok := arrays[prefix]; ok && j < len(key)-1 {
+				path = fmt.Sprintf("%s[%d]", path, n)
+			}
+		}
+		if _, ok := order[path]; !ok {
+			order[path] = i
+		}
+	}
+	return order
+}
+
+// Timestamps are written as TOML datetimes, not strings
+var tomlDatetimes = map[string]bool{"created_at": true, "updated_at": true}
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeTOML rewrites a marshaled pack as TOML. Categories are tables and
+// questions arrays of tables, everything in a question is inline. Keys stay
+// in order, in the order of the file being rewritten when there's one, and
+// multi-line strings stay multi-line. The file's comments stay with the key
+// or table they're above or next to. TOML has no null, unset values are
+// left out
+func encodeTOML(data []byte, order map[string]int, comments map[string]tomlComment) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to convert pack to TOML: %w", err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("failed to convert pack to TOML: a pack must be a mapping")
+	}
+
+	w := &tomlWriter{order: order, comments: comments}
+	w.table(root.Content[0], nil, "")
+	if foot := comments[""].head; foot != "" {
+		w.buf.WriteString("\n" + foot)
+	}
+	return bytes.TrimLeft(w.buf.Bytes(), "\n"), nil
+}
+
+type tomlWriter struct {
+	buf      bytes.Buffer
+	order    map[string]int         // see tomlOrder
+	comments map[string]tomlComment // see tomlComments
+}
+
+// line writes a line of the key or table at path with its comments
+func (w *tomlWriter) line(path, text string) {
+	c := w.comments[path]
+	w.buf.WriteString(c.head + text)
+	if c.line != "" {
+		w.buf.WriteString(" " + c.line)
+	}
+	w.buf.WriteByte('\n')
+}
+
+// table writes the keys of a table, values first since a table's keys end
+// where the next table starts. path is the table's keys, prefix its JSON path
+func (w *tomlWriter) table(node *yaml.Node, path []string, prefix string) {
+	var tables []int
+	for _, i := range w.keys(node, prefix) {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case value.Tag == "!!null":
+		case value.Kind == yaml.MappingNode || isTableArray(value):
+			tables = append(tables, i)
+		case len(path) == 0 && tomlDatetimes[key.Value] && isDatetime(value.Value):
+			w.line(joinPath(prefix, key.Value), tomlKey(key.Value)+" = "+value.Value)
+		default:
+			w.line(joinPath(prefix, key.Value), tomlKey(key.Value)+" = "+w.value(value, joinPath(prefix, key.Value), true))
+		}
+	}
+
+	for _, i := range tables {
+		key, value := node.Content[i], node.Content[i+1]
+		child := append(slices.Clip(path), key.Value)
+		childPrefix := joinPath(prefix, key.Value)
+
+		header := make([]string, len(child))
+		for j, k := range child {
+			header[j] = tomlKey(k)
+		}
+
+		// A table of nothing but tables doesn't need a header
+		if value.Kind == yaml.MappingNode {
+			if _, ok := w.order[childPrefix]; ok || len(value.Content) == 0 || hasTOMLValues(value) {
+				w.buf.WriteByte('\n')
+				w.line(childPrefix, "["+strings.Join(header, ".")+"]")
+			}
+			w.table(value, child, childPrefix)
+			continue
+		}
+
+		for n, item := range value.Content {
+			itemPrefix := fmt.Sprintf("%s[%d]", childPrefix, n)
+			w.buf.WriteByte('\n')
+			w.line(itemPrefix, "[["+strings.Join(header, ".")+"]]")
+			for _, j := range w.keys(item, itemPrefix) {
+				k, v := item.Content[j], item.Content[j+1]
+				if v.Tag != "!!null" {
+					w.line(joinPath(itemPrefix, k.Value), tomlKey(k.Value)+" = "+w.value(v, joinPath(itemPrefix, k.Value), true))
+				}
+			}
+		}
+	}
+}
+
+// value writes a value inline, strings from the JSON are the quoted scalars.
+// Only top level strings are written multi-line, they read the best
+func (w *tomlWriter) value(node *yaml.Node, path string, top bool) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Tag != "!!null" {
+				items = append(items, w.value(item, path, false))
+			}
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+
+	case yaml.MappingNode:
+		pairs := make([]string, 0, len(node.Content)/2)
+		for _, i := range w.keys(node, path) {
+			k, v := node.Content[i], node.Content[i+1]
+			if v.Tag != "!!null" {
+				pairs = append(pairs, tomlKey(k.Value)+" = "+w.value(v, joinPath(path, k.Value), false))
+			}
+		}
+		if len(pairs) == 0 {
+			return "{}"
+		}
+		return "{ " + strings.Join(pairs, ", ") + " }"
+
+	default:
+		if node.Style != yaml.DoubleQuotedStyle {
+			return node.Value // numbers and bools are the same in both
+		}
+		if top && strings.Contains(node.Value, "\n") {
+			return tomlMultiline(node.Value)
+		}
+		return tomlString(node.Value)
+	}
+}
+
+// keys lists the indexes of a mapping's keys in order. A key the file
+// being rewritten doesn't have stays after the key it follows
+func (w *tomlWriter) keys(node *yaml.Node, prefix string) []int {
+	type key struct{ index, rank int }
+	keys := make([]key, 0, len(node.Content)/2)
+
+	rank := -1
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if r, ok := w.order[joinPath(prefix, node.Content[i].Value)]; ok {
+			rank = r
+		}
+		keys = append(keys, key{i, rank})
+	}
+	slices.SortStableFunc(keys, func(a, b key) int { return cmp.Compare(a.rank, b.rank) })
+
+	indexes := make([]int, len(keys))
+	for i, k := range keys {
+		indexes[i] = k.index
+	}
+	return indexes
+}
+
+// hasTOMLValues reports whether a table has keys that aren't tables
+func hasTOMLValues(node *yaml.Node) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		if value.Tag != "!!null" && value.Kind != yaml.MappingNode && !isTableArray(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTableArray reports whether a list is written as an array of tables
+func isTableArray(node *yaml.Node) bool {
+	if node.Kind != yaml.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+func isDatetime(s string) bool {
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			writeTOMLRune(&b, r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tomlMultiline writes s as a multi-line basic string, quotes are
+// only escaped where three in a row would end the string
+func tomlMultiline(s string) string {
+	var b strings.Builder
+	b.WriteString("\"\"\"\n")
+
+	quotes := 0
+	for _, r := range s {
+		if r != '"' {
+			quotes = 0
+		}
+		switch {
+		case r == '"' && quotes == 2:
+			b.WriteString(`\"`)
+			quotes = 0
+		case r == '"':
+			b.WriteRune(r)
+			quotes++
+		case r == '\n':
+			b.WriteRune(r)
+		default:
+			writeTOMLRune(&b, r)
+		}
+	}
+
+	// A quote right before the closing delimiter would end the string early
+	if quotes > 0 {
+		str := b.String()
+		return str[:len(str)-quotes] + strings.Repeat(`\"`, quotes) + `"""`
+	}
+	b.WriteString(`"""`)
+	return b.String()
+}
+
+// writeTOMLRune writes a rune of a basic string, escaping
+// backslashes and the control characters TOML doesn't allow
+func writeTOMLRune(b *strings.Builder, r rune) {
+	switch {
+	case r == '\\':
+		b.WriteString(`\\`)
+	case r == '\t':
+		b.WriteRune(r)
+	case r == '\r':
+		b.WriteString(`\r`)
+	case r < 0x20 || r == 0x7f:
+		fmt.Fprintf(b, `\u%04X`, r)
+	default:
+		b.WriteRune(r)
+	}
+}
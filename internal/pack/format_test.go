@@ -0,0 +1,408 @@
+package pack
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRaw() *Raw {
+	number := 42.5
+	return &Raw{
+		SchemaVersion: SchemaVersion,
+		ID:            "pack_go_basics",
+		Name:          "Go Basics",
+		Role:          "backend developer",
+		Creator:       "Ace Team",
+		CreatedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Categories: map[string]RawCategory{
+			"concurrency": {
+				TextEntry: []RawTextQuestion{{
+					ID:         "goroutine",
+					Difficulty: "junior",
+					Prompt:     "What is a \"goroutine\"?",
+					Expected:   "A lightweight thread\nmanaged by the Go runtime \\ scheduler.\n",
+					Keywords:   []RawKeyword{{Term: "lightweight"}, {Term: "runtime", Synonyms: []string{"rt"}, Weight: 2}},
+				}},
+				Bool: []RawBoolQuestion{{ID: "nil_map", Difficulty: "junior", Prompt: "A nil map can be read from.", Answer: true}},
+			},
+			"language spec": {
+				Choice: []RawChoiceQuestion{{
+					ID:         "zero",
+					Difficulty: "mid",
+					Prompt:     "Zero value of a string?",
+					Options:    []string{`""`, "nil"},
+					RawNotes:   RawNotes{Explanation: "Ends in quotes \"\"", References: []string{"https://go.dev/ref/spec"}},
+				}},
+				ShortAnswer: []RawShortQuestion{{ID: "pi", Difficulty: "senior", Prompt: "Half of 85?", Number: &number, Tolerance: 0.5}},
+				Ordering:    []RawOrderQuestion{{ID: "init", Difficulty: "mid", Prompt: "Order these", Items: []string{"imports", "vars", "init"}}},
+			},
+		},
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	want, err := testRaw().Encode(FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		t.Run(format.String(), func(t *testing.T) {
+			data, err := testRaw().Encode(format)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			raw, err := Unpack(data, format)
+			if err != nil {
+				t.Fatalf("Unpack() error = %v\n%s", err, data)
+			}
+			got, err := raw.Encode(FormatJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("round trip changed the pack\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeLeavesOutUnset(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		data, err := testRaw().Encode(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"updated_at", "\nversion", `"version"`, "multiple_choice", "null"} {
+			if strings.Contains(string(data), key) {
+				t.Errorf("%s: output has %q\n%s", format, key, data)
+			}
+		}
+	}
+}
+
+func TestEncodeTOMLMultiline(t *testing.T) {
+	data, err := testRaw().Encode(FormatTOML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "expected = \"\"\"\nA lightweight thread\nmanaged by the Go runtime \\\\ scheduler.\n\"\"\"\n"
+	if !strings.Contains(string(data), want) {
+		t.Errorf("expected isn't multi-line\n%s", data)
+	}
+	if !strings.Contains(string(data), "created_at = 2024-01-02T03:04:05Z\n") {
+		t.Errorf("created_at isn't a datetime\n%s", data)
+	}
+}
+
+func TestTOMLMultilineQuotes(t *testing.T) {
+	for _, s := range []string{"a\nb", "\"\"\"\n", "ends in a quote\n\"", "two\n\"\"", "x\n\"\"\"\"y"} {
+		doc := "v = " + tomlMultiline(s) + "\n"
+		raw, _, err := decodeTOML([]byte(doc))
+		if err != nil {
+			t.Errorf("%q: %v\n%s", s, err, doc)
+			continue
+		}
+		if want, _ := json.Marshal(map[string]string{"v": s}); !bytes.Equal(raw, want) {
+			t.Errorf("%q: decoded %s, want %s", s, raw, want)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		orig   string
+		change func(r *Raw)
+		want   string
+	}{
+		{
+			name:   "yaml keeps comments and styles",
+			format: FormatYAML,
+			orig: `# Kept by hand
+schema_version: 2
+id: pack_go_basics
+name: Go Basics
+role: backend
+creator: me
+categories:
+  concurrency:
+    text_entry:
+      - id: goroutine
+        difficulty: junior # easy one
+        prompt: "What is a goroutine?"
+        expected: |
+          A lightweight thread.
+        keywords: [lightweight, runtime]
+`,
+			change: func(r *Raw) {
+				q := &r.Categories["concurrency"].TextEntry[0]
+				q.Explanation = "Goroutines are multiplexed onto threads."
+			},
+			want: `# Kept by hand
+schema_version: 2
+id: pack_go_basics
+name: Go Basics
+role: backend
+creator: me
+categories:
+  concurrency:
+    text_entry:
+      - id: goroutine
+        difficulty: junior # easy one
+        prompt: "What is a goroutine?"
+        expected: |
+          A lightweight thread.
+        keywords: [lightweight, runtime]
+        explanation: Goroutines are multiplexed onto threads.
+`,
+		},
+		{
+			name:   "toml keeps key order",
+			format: FormatTOML,
+			orig: `schema_version = 2
+name = "Go Basics"
+id = "pack_go_basics"
+role = "backend"
+creator = "me"
+
+[[categories.concurrency.text_entry]]
+prompt = "What is a goroutine?"
+difficulty = "junior"
+keywords = ["lightweight"]
+id = "goroutine"
+
+[[categories.concurrency.text_entry]]
+difficulty = "junior"
+prompt = "What does a channel do?"
+keywords = ["send"]
+`,
+			change: func(r *Raw) {
+				r.Categories["concurrency"].TextEntry[1].ID = "channel"
+			},
+			want: `schema_version = 2
+name = "Go Basics"
+id = "pack_go_basics"
+role = "backend"
+creator = "me"
+
+[[categories.concurrency.text_entry]]
+prompt = "What is a goroutine?"
+difficulty = "junior"
+keywords = ["lightweight"]
+id = "goroutine"
+
+[[categories.concurrency.text_entry]]
+id = "channel"
+difficulty = "junior"
+prompt = "What does a channel do?"
+keywords = ["send"]
+`,
+		},
+		{
+			name:   "yaml adds keys below the head comment",
+			format: FormatYAML,
+			orig: `# Kept by hand
+id: pack_go_basics
+name: Go Basics
+role: backend
+creator: me
+categories: {}
+`,
+			change: func(r *Raw) {},
+			want: `# Kept by hand
+schema_version: 2
+id: pack_go_basics
+name: Go Basics
+role: backend
+creator: me
+categories: {}
+`,
+		},
+		{
+			name:   "toml keeps comments",
+			format: FormatTOML,
+			orig: `# Kept by hand
+
+schema_version = 2
+id = "pack_go_basics" # don't change
+name = "Go Basics"
+role = "backend"
+creator = "me"
+
+# The hard ones
+[[categories.concurrency.text_entry]]
+id = "goroutine"
+difficulty = "junior"
+# Asked a lot
+prompt = "What is a #goroutine?" # with a hash
+keywords = ["lightweight"]
+
+# The end
+`,
+			change: func(r *Raw) {
+				r.Categories["concurrency"].TextEntry[0].Expected = "A lightweight thread"
+			},
+			want: `# Kept by hand
+
+schema_version = 2
+id = "pack_go_basics" # don't change
+name = "Go Basics"
+role = "backend"
+creator = "me"
+
+# The hard ones
+[[categories.concurrency.text_entry]]
+id = "goroutine"
+difficulty = "junior"
+# Asked a lot
+prompt = "What is a #goroutine?" # with a hash
+expected = "A lightweight thread"
+keywords = ["lightweight"]
+
+# The end
+`,
+		},
+		{
+			name:   "json keeps key order, indentation and line endings",
+			format: FormatJSON,
+			orig: strings.ReplaceAll(`{
+    "name": "P",
+    "id": "p",
+    "creator": "c",
+    "role": "r",
+    "categories": {
+      "basics": {
+        "choice": [
+          {"id": "q", "difficulty": "junior", "prompt": "<b>?</b>", "options": ["a","b"], "answer": 1}
+        ],
+        "multiple_choice": [
+          {
+            "id": "m",
+            "difficulty": "junior",
+            "prompt": "Which?",
+            "options": ["a", "b", "c"],
+            "answer": [0,1]
+          }
+        ]
+      }
+    }
+  }
+`, "\n", "\r\n"),
+			change: func(r *Raw) {
+				r.Name = "Pack"
+				r.Categories["basics"].MultipleChoice[0].Explanation = "Both"
+			},
+			want: strings.ReplaceAll(`{
+    "schema_version": 2,
+    "name": "Pack",
+    "id": "p",
+    "creator": "c",
+    "role": "r",
+    "categories": {
+      "basics": {
+        "choice": [
+          {"id": "q", "difficulty": "junior", "prompt": "<b>?</b>", "options": ["a","b"], "answer": 1}
+        ],
+        "multiple_choice": [
+          {
+            "id": "m",
+            "difficulty": "junior",
+            "prompt": "Which?",
+            "options": ["a", "b", "c"],
+            "answer": [0,1],
+            "explanation": "Both"
+          }
+        ]
+      }
+    }
+  }
+`, "\n", "\r\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := Unpack([]byte(tt.orig), tt.format)
+			if err != nil {
+				t.Fatalf("Unpack() error = %v", err)
+			}
+			tt.change(raw)
+
+			got, err := raw.Rewrite([]byte(tt.orig), tt.format)
+			if err != nil {
+				t.Fatalf("Rewrite() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Rewrite() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteRepairedID(t *testing.T) {
+	tests := []struct {
+		format Format
+		orig   string
+	}{
+		{FormatJSON, `{
+  "schema_version": 2,
+  "id": null,
+  "name": "P",
+  "creator": "c",
+  "role": "r",
+  "categories": {
+    "basics": {
+      "bool": [{"id": null, "difficulty": "junior", "prompt": "Is it?", "answer": true}]
+    }
+  }
+}
+`},
+		{FormatYAML, `schema_version: 2
+id: null
+name: P
+creator: c
+role: r
+categories:
+  basics:
+    bool:
+      - id: ~
+        difficulty: junior
+        prompt: Is it?
+        answer: true
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			raw, err := Unpack([]byte(tt.orig), tt.format)
+			if err != nil {
+				t.Fatalf("Unpack() error = %v", err)
+			}
+			if report := raw.Repair(); report.Repaired == 0 {
+				t.Fatal("Repair() repaired nothing")
+			}
+
+			data, err := raw.Rewrite([]byte(tt.orig), tt.format)
+			if err != nil {
+				t.Fatalf("Rewrite() error = %v", err)
+			}
+			repaired, err := Unpack(data, tt.format)
+			if err != nil {
+				t.Fatalf("Unpack() of the repaired pack error = %v\n%s", err, data)
+			}
+			if report := repaired.Verify(); report.HasErrors() {
+				t.Errorf("repaired pack has errors %v\n%s", report.Errors, data)
+			}
+			if repaired.ID != raw.ID || repaired.Categories["basics"].Bool[0].ID != raw.Categories["basics"].Bool[0].ID {
+				t.Errorf("IDs weren't written\n%s", data)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package pack
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 )
 
@@ -33,23 +36,81 @@ func Read(filepath string) ([]byte, error) {
 	return data, nil
 }
 
-func Unpack(data []byte) (*Raw, error) {
-	var raw Raw
-	err := json.Unmarshal(data, &raw)
+// Unpack decodes a pack written in format, upgrading older schemas on the
+// way. Keys nothing reads end up as warnings in the pack's Verify report,
+// and a pack that doesn't decode fails with a *SourceError saying where
+func Unpack(data []byte, format Format) (*Raw, error) {
+	src, locate, err := decodeSource(data, format)
 	if err != nil {
-		return nil, ErrInvalidJSON
+		return nil, err
 	}
 
+	var doc map[string]any
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, syntaxError(data, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		pos := positionAt(data, valueStart(data, dec.InputOffset()))
+		return nil, &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: "unexpected data after the pack", Snippet: Snippet(data, pos)}
+	}
+
+	readVersion, err := migrate(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index under the migrated names, that's what every path refers to
+	var rename func(path, key string) string
+	if readVersion < SchemaVersion {
+		rename = migratedKey
+	}
+	pos := locate(rename)
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal migrated pack: %w", err)
+	}
+
+	var raw Raw
+	if err := json.Unmarshal(migrated, &raw); err != nil {
+		return nil, typeError(data, migrated, pos, err)
+	}
+
+	raw.readVersion = readVersion
+	raw.unknown = unknownKeys(doc, reflect.TypeFor[Raw](), "")
+	raw.positions = pos
+
 	return &raw, nil
 }
 
-func LoadAll() ([]*Pack, error) {
+// LoadError is a pack file LoadAll had to skip
+type LoadError struct {
+	File string
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return e.File + ": " + e.Err.Error()
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadAll loads every pack in the packs folder. Packs that fail
+// are skipped and come back as LoadErrors, they don't stop the rest
+func LoadAll() ([]*Pack, []*LoadError, error) {
 	files, err := os.ReadDir(packPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var packs []*Pack
+	var (
+		packs  []*Pack
+		failed []*LoadError
+	)
 	prefix := "pack_"
 
 	for _, file := range files {
@@ -61,7 +122,7 @@ func LoadAll() ([]*Pack, error) {
 			continue
 		}
 
-		if filepath.Ext(file.Name()) != ".json" {
+		if _, ok := FormatOf(file.Name()); !ok {
 			continue
 		}
 
@@ -70,14 +131,14 @@ func LoadAll() ([]*Pack, error) {
 		// Use Load() which handles everything
 		pack, err := Load(filePath)
 		if err != nil {
-			fmt.Printf("Warning: Failed to load %s: %v", filePath, err)
+			failed = append(failed, &LoadError{File: filePath, Err: err})
 			continue
 		}
 
 		packs = append(packs, pack)
 	}
 
-	return packs, nil
+	return packs, failed, nil
 }
 
 func Load(filepath string) (*Pack, error) {
@@ -86,7 +147,8 @@ func Load(filepath string) (*Pack, error) {
 		return nil, err
 	}
 
-	raw, err := Unpack(data)
+	format, _ := FormatOf(filepath)
+	raw, err := Unpack(data, format)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +166,8 @@ func Load(filepath string) (*Pack, error) {
 	if hasRepairableIssues {
 		repairReport := raw.Repair()
 
-		if repairReport.Repaired > 0 {
+		// Markdown notes are repaired every time they load, never rewritten
+		if repairReport.Repaired > 0 && format.Writable() {
 			if err := raw.Save(filepath); err != nil {
 				return nil, fmt.Errorf("failed to save repaired pack: %w", err)
 			}
@@ -113,10 +176,10 @@ func Load(filepath string) (*Pack, error) {
 		finalReport := raw.Verify()
 
 		if len(finalReport.Errors) > 0 {
-			return nil, fmt.Errorf("pack validation failed: %d errors after repair", len(finalReport.Errors))
+			return nil, verifyError(data, finalReport)
 		}
 	} else if len(initialReport.Errors) > 0 {
-		return nil, fmt.Errorf("pack validation failed: %d errors", len(initialReport.Errors))
+		return nil, verifyError(data, initialReport)
 	}
 
 	pack := raw.ToDomain(filepath)
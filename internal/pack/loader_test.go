@@ -0,0 +1,49 @@
+package pack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+func TestLoadQuestionTypes(t *testing.T) {
+	p, err := Load("testdata/pack_question_types.json")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	byID := make(map[string]engine.Question)
+	for _, q := range p.Questions {
+		byID[q.ID] = q.ToEngine()
+	}
+
+	port := 443.0
+	tests := []struct {
+		id   string
+		want engine.Question
+	}{
+		{"docker_port_flag", engine.ShortAnswerQuestion{
+			BaseQuestion:  engine.BaseQuestion{ID: "docker_port_flag", Difficulty: engine.Entry, Prompt: "Which docker run flag publishes a container port to the host?"},
+			Accepted:      []string{"-p", "--publish"},
+			CaseSensitive: true,
+		}},
+		{"https_port", engine.ShortAnswerQuestion{
+			BaseQuestion: engine.BaseQuestion{ID: "https_port", Difficulty: engine.Entry, Prompt: "What port does HTTPS use by default?"},
+			Number:       &port,
+		}},
+		{"tcp_handshake", engine.OrderingQuestion{
+			BaseQuestion: engine.BaseQuestion{ID: "tcp_handshake", Difficulty: engine.Junior, Prompt: "Put the steps of the TCP handshake in order."},
+			Items:        []string{"Client sends SYN", "Server replies SYN-ACK", "Client sends ACK"},
+		}},
+	}
+
+	if len(byID) != len(tests) {
+		t.Errorf("loaded %d questions, want %d", len(byID), len(tests))
+	}
+	for _, tt := range tests {
+		if got := byID[tt.id]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %+v, want %+v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,432 @@
+package pack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotWritable is returned when saving a pack in a format that's only read,
+// Markdown notes are compiled into a pack rather than written back
+var ErrNotWritable = errors.New("format can't be written")
+
+// Markdown packs are interview notes with questions in them:
+//
+//	---
+//	id: pack_go_notes
+//	name: Go Notes
+//	role: backend developer
+//	creator: Ace Team
+//	---
+//
+//	## Concurrency
+//
+//	Anything that isn't a question is left alone.
+//
+//	- What does `go` before a call do? #junior {#go_keyword}
+//	  - [ ] Runs it in a new process
+//	  - [x] Runs it in a new goroutine
+//	  - Explanation: The call runs concurrently with the caller.
+//	- Which of these are reference types? #mid
+//	  - [x] map
+//	  - [x] slice
+//	  - [ ] array
+//	- A nil map can be read from. #junior
+//	  - True
+//	- What is a goroutine? #junior
+//	  > A lightweight thread managed by the Go runtime.
+//	  - Keywords: lightweight, runtime, thread
+//
+// Front matter holds the pack fields and every ## heading starts a category.
+// A top level list item is a question when it has a tag or an answer under
+// it. The tag is its difficulty, #multi makes a single checked option a
+// multiple choice question and {#id} sets its ID. Under the question:
+//
+//   - checkboxes are options, checked ones the answer
+//   - a True or False item is the answer to a true/false question
+//   - quoted lines are the expected answer to a text question
+//   - Keywords, Explanation, Hint and See items fill in the rest
+var (
+	mdHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdItem     = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(.*)$`)
+	mdCheckbox = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	mdField    = regexp.MustCompile(`^(?i)(keywords|explanation|hint|see|references?):\s*(.*)$`)
+	mdTag      = regexp.MustCompile(`^#([\w-]+)$`)
+	mdID       = regexp.MustCompile(`^\{#([^}\s]+)\}$`)
+	mdSlug     = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// mdQuestion is a question as it's read, before its type is known
+type mdQuestion struct {
+	line       int
+	id         string
+	difficulty string
+	multi      bool
+	prompt     []string
+
+	options []mdOption
+	boolean *mdValue // true/false answer
+
+	expected     []string
+	expectedLine int
+	fields       map[string]mdValue // lowercased field name -> value
+}
+
+type mdOption struct {
+	text    string
+	checked bool
+	line    int
+}
+
+type mdValue struct {
+	text string
+	line int
+}
+
+func (q *mdQuestion) hasAnswer() bool {
+	return len(q.options) > 0 || q.boolean != nil || len(q.expected) > 0 || len(q.fields) > 0
+}
+
+func decodeMarkdown(data []byte) ([]byte, func(func(path, key string) string) map[string]Position, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	pos := make(map[string]Position)
+
+	doc := map[string]any{"schema_version": SchemaVersion}
+	body, err := frontMatter(data, lines, doc, pos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categories := make(map[string]map[string][]any)
+	var (
+		order    []string // categories in the order they appear
+		category string
+		question *mdQuestion
+		fenced   bool
+	)
+
+	flush := func() {
+		if question == nil || (!question.hasAnswer() && question.difficulty == "" && !question.multi) {
+			question = nil
+			return
+		}
+
+		if category == "" {
+			category = "general"
+			order = append(order, category)
+			pos[joinPath("categories", category)] = Position{Line: question.line, Col: 1}
+		}
+		if categories[category] == nil {
+			categories[category] = make(map[string][]any)
+		}
+
+		list, value := question.compile()
+		path := questionPath(category, list, len(categories[category][list]))
+		question.locate(path, lines, pos)
+		categories[category][list] = append(categories[category][list], value)
+		question = nil
+	}
+
+	for n := body; n < len(lines); n++ {
+		line := lines[n]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if question != nil && isNested(line) {
+				question.prompt = append(question.prompt, strings.TrimSpace(line))
+				continue
+			}
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			if question != nil && isNested(line) {
+				question.prompt = append(question.prompt, strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		if trimmed == "" {
+			continue
+		}
+
+		if !isNested(line) {
+			if m := mdHeading.FindStringSubmatch(line); m != nil {
+				flush()
+				if len(m[1]) == 2 {
+					category = slug(m[2])
+					if _, ok := pos[joinPath("categories", category)]; !ok {
+						order = append(order, category)
+						pos[joinPath("categories", category)] = Position{Line: n + 1, Col: 1}
+					}
+				}
+				continue
+			}
+
+			flush()
+			if m := mdItem.FindStringSubmatch(line); m != nil {
+				question = newQuestion(m[1], n+1)
+			}
+			continue
+		}
+
+		if question != nil {
+			question.add(trimmed, n+1)
+		}
+	}
+	flush()
+
+	docCategories := make(map[string]any, len(order))
+	for _, name := range order {
+		// Headings without questions are just notes
+		if len(categories[name]) == 0 {
+			continue
+		}
+		lists := make(map[string]any, len(categories[name]))
+		for list, questions := range categories[name] {
+			lists[list] = questions
+		}
+		docCategories[name] = lists
+	}
+	doc["categories"] = docCategories
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, &SourceError{Err: ErrInvalidJSON, Msg: err.Error()}
+	}
+
+	// Markdown is always read in the current schema
+	locate := func(func(path, key string) string) map[string]Position {
+		return pos
+	}
+	return out, locate, nil
+}
+
+// frontMatter decodes the YAML between the leading --- lines into doc and
+// returns the line the notes start at
+func frontMatter(data []byte, lines []string, doc map[string]any, pos map[string]Position) (int, error) {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return 0, nil
+	}
+
+	end := -1
+	for n := 1; n < len(lines); n++ {
+		if t := strings.TrimSpace(lines[n]); t == "---" || t == "..." {
+			end = n
+			break
+		}
+	}
+	if end < 0 {
+		p := Position{Line: 1, Col: 1}
+		return 0, &SourceError{Err: ErrInvalidJSON, Pos: p, Msg: "front matter is never closed with ---", Snippet: Snippet(data, p)}
+	}
+
+	// A blank line for the opening --- keeps the parser on the file's line numbers
+	src := "\n" + strings.Join(lines[1:end], "\n")
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(src), &root); err != nil {
+		return 0, yamlError(data, err)
+	}
+	if len(root.Content) == 0 {
+		return end + 1, nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		p := Position{Line: mapping.Line, Col: mapping.Column}
+		return 0, &SourceError{Err: ErrInvalidJSON, Pos: p, Msg: "front matter must be a mapping", Snippet: Snippet(data, p)}
+	}
+
+	var fields map[string]any
+	if err := mapping.Decode(&fields); err != nil {
+		return 0, yamlError(data, err)
+	}
+	for key, value := range fields {
+		doc[key] = value
+	}
+	walkYAML(mapping, "", nil, pos)
+
+	return end + 1, nil
+}
+
+func isNested(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// slug turns a heading into a category key, "CI / CD" becomes "ci_cd"
+func slug(heading string) string {
+	return strings.Trim(mdSlug.ReplaceAllString(strings.ToLower(heading), "_"), "_")
+}
+
+// newQuestion reads the tags off the end of a question's first line
+func newQuestion(text string, line int) *mdQuestion {
+	q := &mdQuestion{line: line, fields: make(map[string]mdValue)}
+
+	words := strings.Fields(text)
+	for len(words) > 0 {
+		last := words[len(words)-1]
+		if m := mdID.FindStringSubmatch(last); m != nil {
+			q.id = m[1]
+		} else if m := mdTag.FindStringSubmatch(last); m != nil {
+			if strings.EqualFold(m[1], "multi") {
+				q.multi = true
+			} else {
+				q.difficulty = strings.ToLower(m[1])
+			}
+		} else {
+			break
+		}
+		words = words[:len(words)-1]
+	}
+
+	q.prompt = []string{strings.Join(words, " ")}
+	return q
+}
+
+// add reads a line nested under the question
+func (q *mdQuestion) add(text string, line int) {
+	if quoted, ok := strings.CutPrefix(text, ">"); ok {
+		if len(q.expected) == 0 {
+			q.expectedLine = line
+		}
+		q.expected = append(q.expected, strings.TrimSpace(quoted))
+		return
+	}
+
+	m := mdItem.FindStringSubmatch(text)
+	if m == nil {
+		q.prompt = append(q.prompt, text)
+		return
+	}
+	item := m[1]
+
+	if c := mdCheckbox.FindStringSubmatch(item); c != nil {
+		q.options = append(q.options, mdOption{text: c[2], checked: c[1] != " ", line: line})
+		return
+	}
+
+	if strings.EqualFold(item, "true") || strings.EqualFold(item, "false") {
+		q.boolean = &mdValue{text: strings.ToLower(item), line: line}
+		return
+	}
+
+	if f := mdField.FindStringSubmatch(item); f != nil {
+		name := strings.ToLower(f[1])
+		if name == "reference" || name == "references" {
+			name = "see"
+		}
+
+		// See can be given more than once
+		if prev, ok := q.fields[name]; ok && name == "see" {
+			q.fields[name] = mdValue{text: prev.text + "\n" + f[2], line: prev.line}
+			return
+		}
+		q.fields[name] = mdValue{text: f[2], line: line}
+		return
+	}
+
+	q.prompt = append(q.prompt, text)
+}
+
+// compile builds the question in the pack's JSON layout
+// and returns it with the list it goes in
+func (q *mdQuestion) compile() (string, map[string]any) {
+	value := map[string]any{
+		"id":         q.id,
+		"difficulty": q.difficulty,
+		"prompt":     strings.Join(q.prompt, "\n"),
+	}
+	if f, ok := q.fields["explanation"]; ok {
+		value["explanation"] = f.text
+	}
+	if f, ok := q.fields["hint"]; ok {
+		value["hint"] = f.text
+	}
+	if f, ok := q.fields["see"]; ok {
+		value["references"] = strings.Split(f.text, "\n")
+	}
+
+	switch {
+	case len(q.options) > 0:
+		var (
+			options []string
+			checked []int
+		)
+		for i, o := range q.options {
+			options = append(options, o.text)
+			if o.checked {
+				checked = append(checked, i)
+			}
+		}
+		value["options"] = options
+
+		if len(checked) == 1 && !q.multi {
+			value["answer"] = checked[0]
+			return "choice", value
+		}
+		value["answer"] = checked
+		return "multiple_choice", value
+
+	case q.boolean != nil:
+		value["answer"] = q.boolean.text == "true"
+		return "bool", value
+
+	default:
+		value["expected"] = strings.Join(q.expected, "\n")
+
+		keywords := []string{}
+		if f, ok := q.fields["keywords"]; ok {
+			for k := range strings.SplitSeq(f.text, ",") {
+				if k = strings.TrimSpace(k); k != "" {
+					keywords = append(keywords, k)
+				}
+			}
+		}
+		value["keywords"] = keywords
+		return "text_entry", value
+	}
+}
+
+// locate records the lines the question's values came from under path
+func (q *mdQuestion) locate(path string, lines []string, pos map[string]Position) {
+	at := func(line int) Position {
+		text := lines[line-1]
+		return Position{Line: line, Col: len(text) - len(strings.TrimLeft(text, " \t")) + 1}
+	}
+
+	pos[path] = at(q.line)
+	for _, key := range []string{"id", "difficulty", "prompt"} {
+		pos[path+"."+key] = at(q.line)
+	}
+
+	for i, o := range q.options {
+		pos[fmt.Sprintf("%s.options[%d]", path, i)] = at(o.line)
+		if o.checked {
+			if _, ok := pos[path+".answer"]; !ok {
+				pos[path+".answer"] = at(o.line)
+			}
+		}
+	}
+	if len(q.options) > 0 {
+		pos[path+".options"] = at(q.options[0].line)
+	}
+
+	if q.boolean != nil {
+		pos[path+".answer"] = at(q.boolean.line)
+	}
+	if q.expectedLine > 0 {
+		pos[path+".expected"] = at(q.expectedLine)
+	}
+
+	names := map[string]string{"keywords": "keywords", "explanation": "explanation", "hint": "hint", "see": "references"}
+	for field, key := range names {
+		if f, ok := q.fields[field]; ok {
+			pos[path+"."+key] = at(f.line)
+		}
+	}
+}
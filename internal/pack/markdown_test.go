@@ -0,0 +1,135 @@
+package pack
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+const goNotes = `---
+id: pack_go_notes
+name: Go Notes
+role: backend developer
+creator: Ace Team
+---
+
+Notes before the first heading are left alone.
+
+## Concurrency
+
+- What does ` + "`go`" + ` before a call do? #junior {#go_keyword}
+  - [ ] Runs it in a new process
+  - [x] Runs it in a new goroutine
+  - Explanation: The call runs concurrently with the caller.
+- Which of these are reference types? #mid
+  - [x] map
+  - [x] slice
+  - [ ] array
+- Is a single answer still multiple choice? #mid #multi
+  - [x] Yes
+  - [ ] No
+- A nil map can be read from. #junior
+  - True
+- What is a goroutine? #junior
+  > A lightweight thread managed by the Go runtime.
+  - Keywords: lightweight, runtime, thread
+- Just a bullet in the notes
+
+## Empty Heading
+
+` + "```" + `
+- Not a question #junior
+` + "```" + `
+`
+
+func TestDecodeMarkdown(t *testing.T) {
+	raw, err := Unpack([]byte(goNotes), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+
+	if raw.ID != "pack_go_notes" || raw.Name != "Go Notes" || raw.Creator != "Ace Team" {
+		t.Errorf("front matter = %q, %q, %q", raw.ID, raw.Name, raw.Creator)
+	}
+	if _, ok := raw.Categories["empty-heading"]; ok || len(raw.Categories) != 1 {
+		t.Errorf("categories = %v, want just concurrency", slices.Collect(maps.Keys(raw.Categories)))
+	}
+
+	c := raw.Categories["concurrency"]
+	if len(c.Choice) != 1 || len(c.MultipleChoice) != 2 || len(c.Bool) != 1 || len(c.TextEntry) != 1 {
+		t.Fatalf("got %d choice, %d multi, %d bool, %d text, want 1, 2, 1, 1",
+			len(c.Choice), len(c.MultipleChoice), len(c.Bool), len(c.TextEntry))
+	}
+
+	choice := c.Choice[0]
+	if choice.ID != "go_keyword" || choice.Difficulty != "junior" || choice.Answer != 1 || choice.Explanation == "" {
+		t.Errorf("choice = %+v", choice)
+	}
+	if got := c.MultipleChoice[0].Answer; !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("multiple choice answer = %v, want [0 1]", got)
+	}
+	if got := c.MultipleChoice[1].Answer; !slices.Equal(got, []int{0}) {
+		t.Errorf("#multi answer = %v, want [0]", got)
+	}
+	if !c.Bool[0].Answer {
+		t.Errorf("bool answer = false, want true")
+	}
+
+	text := c.TextEntry[0]
+	if text.Expected != "A lightweight thread managed by the Go runtime." {
+		t.Errorf("expected = %q", text.Expected)
+	}
+	var terms []string
+	for _, k := range text.Keywords {
+		terms = append(terms, k.Term)
+	}
+	if !slices.Equal(terms, []string{"lightweight", "runtime", "thread"}) {
+		t.Errorf("keywords = %v", terms)
+	}
+}
+
+func TestDecodeMarkdownLocates(t *testing.T) {
+	raw, err := Unpack([]byte(goNotes), FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]int{ // path -> line
+		"id":                                   2,
+		"categories.concurrency":               10,
+		"categories.concurrency.choice[0]":     12,
+		"categories.concurrency.bool[0]":       23,
+		"categories.concurrency.text_entry[0]": 25,
+	}
+	for path, line := range tests {
+		if got := raw.positions[path].Line; got != line {
+			t.Errorf("%s at line %d, want %d", path, got, line)
+		}
+	}
+}
+
+func TestDecodeMarkdownErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		line int
+	}{
+		{"front matter never closed", "---\nid: p\n\n## Go\n", 1},
+		{"front matter isn't a mapping", "---\n- id\n---\n", 2},
+		{"front matter doesn't parse", "---\nid: p\n  name: x\n---\n", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Unpack([]byte(tt.doc), FormatMarkdown)
+			var srcErr *SourceError
+			if !errors.As(err, &srcErr) {
+				t.Fatalf("Unpack() error = %v, want a *SourceError", err)
+			}
+			if srcErr.Pos.Line != tt.line {
+				t.Errorf("error at line %d, want %d: %v", srcErr.Pos.Line, tt.line, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 const (
@@ -76,8 +78,9 @@ func (m *Metadata) LoadPack(packID string) (*Pack, error) {
 		return nil, fmt.Errorf("pack %s not found in metadata", packID)
 	}
 
-	// Load pack from file
-	pack, err := Load(info.Path)
+	// Load pack from file, the metadata may have been built on another OS
+	path := filepath.FromSlash(strings.ReplaceAll(info.Path, `\`, "/"))
+	pack, err := Load(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load pack %s: %w", packID, err)
 	}
@@ -0,0 +1,321 @@
+package pack
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a place in a pack file, line and column both start at 1.
+// The zero Position means the place isn't known
+type Position struct {
+	Line int
+	Col  int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("line %d, col %d", p.Line, p.Col)
+}
+
+// SourceError is a problem with a pack file and where in the file it is.
+// It wraps ErrInvalidJSON when the pack doesn't decode and ErrInvalidData
+// when it doesn't pass verification
+type SourceError struct {
+	Err     error
+	Pos     Position
+	Path    string // JSON path of the offending value, empty for syntax errors
+	Msg     string
+	Snippet string // the offending line with a caret under Pos
+}
+
+func (e *SourceError) Error() string {
+	var s string
+	if e.Pos.IsValid() {
+		s = e.Pos.String() + ": "
+	}
+	if e.Path != "" {
+		s += e.Path + ": "
+	}
+	return s + e.Msg
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Snippet returns the line of src at pos with a caret under the column,
+// ready to print below an error. It's empty when pos isn't in src
+func Snippet(src []byte, pos Position) string {
+	lines := strings.Split(string(src), "\n")
+	if !pos.IsValid() || pos.Line > len(lines) {
+		return ""
+	}
+
+	line := strings.TrimRight(lines[pos.Line-1], "\r")
+	gutter := fmt.Sprintf("%d | ", pos.Line)
+
+	// Keep tabs so the caret lines up however they're rendered
+	var caret strings.Builder
+	caret.WriteString(strings.Repeat(" ", len(gutter)-2) + "| ")
+	for i, r := range []rune(line) {
+		if i >= pos.Col-1 {
+			break
+		}
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+
+	return gutter + line + "\n" + caret.String()
+}
+
+// positionAt turns a byte offset of src into a Position
+func positionAt(src []byte, offset int) Position {
+	offset = min(max(offset, 0), len(src))
+
+	before := src[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+
+	return Position{
+		Line: bytes.Count(before, []byte("\n")) + 1,
+		Col:  utf8.RuneCount(before[lineStart:]) + 1,
+	}
+}
+
+// A span is where a value starts in the source, by its JSON path.
+// Object members start at their key
+type span struct {
+	path   string
+	offset int
+}
+
+// index lists the start of every value in a valid JSON document,
+// in source order. rename maps keys to the names they're indexed under
+func index(src []byte, rename func(path, key string) string) []span {
+	var spans []span
+	dec := json.NewDecoder(bytes.NewReader(src))
+	_ = walkJSON(dec, src, "", rename, &spans)
+	return spans
+}
+
+func walkJSON(dec *json.Decoder, src []byte, path string, rename func(path, key string) string, spans *[]span) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			offset := valueStart(src, dec.InputOffset())
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+
+			key, _ := tok.(string)
+			if rename != nil {
+				key = rename(path, key)
+			}
+
+			child := joinPath(path, key)
+			*spans = append(*spans, span{path: child, offset: offset})
+			if err := walkJSON(dec, src, child, rename, spans); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; dec.More(); i++ {
+			child := fmt.Sprintf("%s[%d]", path, i)
+			*spans = append(*spans, span{path: child, offset: valueStart(src, dec.InputOffset())})
+			if err := walkJSON(dec, src, child, rename, spans); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// valueStart skips the separators the decoder hasn't consumed yet
+func valueStart(src []byte, offset int64) int {
+	i := int(offset)
+	for i < len(src) && strings.IndexByte(" \t\r\n,:", src[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// positions maps every JSON path in spans to its place in src,
+// the first one wins when two lists were joined by a migration
+func positions(src []byte, spans []span) map[string]Position {
+	pos := make(map[string]Position, len(spans))
+	for _, s := range spans {
+		if _, ok := pos[s.path]; !ok {
+			pos[s.path] = positionAt(src, s.offset)
+		}
+	}
+	return pos
+}
+
+// syntaxError locates a failure to parse src at all
+func syntaxError(src []byte, err error) error {
+	var (
+		syntax  *json.SyntaxError
+		typeErr *json.UnmarshalTypeError
+	)
+
+	offset := len(src)
+	msg := err.Error()
+
+	switch {
+	case errors.As(err, &syntax):
+		// Offset is just past the byte that broke it
+		offset = int(syntax.Offset) - 1
+	case errors.As(err, &typeErr):
+		offset = valueStart(src, 0)
+		msg = "a pack must be a JSON object, got " + typeErr.Value
+	case errors.Is(err, io.EOF):
+		msg = "the file is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		msg = "unexpected end of file"
+	}
+
+	pos := positionAt(src, offset)
+	return &SourceError{Err: ErrInvalidJSON, Pos: pos, Msg: msg, Snippet: Snippet(src, pos)}
+}
+
+// typeError locates a value of the wrong type. The error comes from
+// decoding migrated, its offset is found there and the path is looked up in src
+func typeError(src, migrated []byte, pos map[string]Position, err error) error {
+	typeErr, ok := err.(*json.UnmarshalTypeError)
+	if !ok {
+		return &SourceError{Err: ErrInvalidJSON, Msg: err.Error()}
+	}
+
+	// The offending value is the last one to start before the offset
+	var path string
+	for _, s := range index(migrated, nil) {
+		if int64(s.offset) >= typeErr.Offset {
+			break
+		}
+		path = s.path
+	}
+
+	p := pos[path]
+	return &SourceError{
+		Err:     ErrInvalidJSON,
+		Pos:     p,
+		Path:    path,
+		Msg:     fmt.Sprintf("expected %s, got %s", typeName(typeErr.Type), typeErr.Value),
+		Snippet: Snippet(src, p),
+	}
+}
+
+// verifyError is a pack that failed Verify, located at its first error
+func verifyError(src []byte, report Report) error {
+	first := report.Errors[0]
+	msg := first.Message
+	if first.Ref != "" {
+		msg += " (" + first.Ref + ")"
+	}
+
+	err := &SourceError{Err: ErrInvalidData, Pos: first.Pos, Msg: msg, Snippet: Snippet(src, first.Pos)}
+	if n := len(report.Errors); n > 1 {
+		return fmt.Errorf("pack validation failed: %w, and %d more errors", err, n-1)
+	}
+	return fmt.Errorf("pack validation failed: %w", err)
+}
+
+// typeName describes t in the words of a pack author
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		if t == timeType {
+			return "timestamp"
+		}
+		return "object"
+	default:
+		return t.String()
+	}
+}
+
+// locate fills in where every issue is in the file the pack was unpacked
+// from. An issue about a value that isn't in the file, a missing prompt say,
+// points at the nearest thing around it that is
+func (r *Raw) locate(report Report) Report {
+	if r.positions == nil {
+		return report
+	}
+
+	for _, issues := range [][]Issue{report.Errors, report.Warnings} {
+		for i := range issues {
+			for path := issues[i].Path; path != ""; path = parentPath(path) {
+				if pos, ok := r.positions[path]; ok {
+					issues[i].Pos = pos
+					break
+				}
+			}
+		}
+	}
+	return report
+}
+
+// parentPath drops the last key or index of a JSON path,
+// categories.go.choice[0].prompt becomes categories.go.choice[0]
+// and categories["node.js"] categories, see joinPath
+func parentPath(path string) string {
+	last := 0 // start of the last key or index
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			last = i
+			i++
+		case '[':
+			last = i
+			if quoted, err := strconv.QuotedPrefix(path[i+1:]); err == nil {
+				i += len(quoted) + 2
+				continue
+			}
+			if end := strings.IndexByte(path[i:], ']'); end >= 0 {
+				i += end + 1
+				continue
+			}
+			return ""
+		default:
+			i++
+		}
+	}
+	return path[:last]
+}
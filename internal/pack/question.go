@@ -16,6 +16,8 @@ const (
 	TypeMulti
 	TypeBool
 	TypeText
+	TypeShort
+	TypeOrder
 )
 
 func (t Type) String() string {
@@ -28,6 +30,10 @@ func (t Type) String() string {
 		return "bool"
 	case TypeText:
 		return "text"
+	case TypeShort:
+		return "short"
+	case TypeOrder:
+		return "order"
 	default:
 		return ""
 	}
@@ -44,11 +50,35 @@ func FromEngineType(et engine.QuestionType) Type {
 		return TypeBool
 	case engine.TextEntry:
 		return TypeText
+	case engine.ShortAnswer:
+		return TypeShort
+	case engine.Ordering:
+		return TypeOrder
 	default:
 		return TypeChoice // or panic/error
 	}
 }
 
+// ToEngine converts a pack.Type to an engine.QuestionType
+func (t Type) ToEngine() engine.QuestionType {
+	switch t {
+	case TypeChoice:
+		return engine.Choice
+	case TypeMulti:
+		return engine.MultipleChoice
+	case TypeBool:
+		return engine.Bool
+	case TypeText:
+		return engine.TextEntry
+	case TypeShort:
+		return engine.ShortAnswer
+	case TypeOrder:
+		return engine.Ordering
+	default:
+		return 0
+	}
+}
+
 // FromEngineTypes converts multiple engine types to pack types
 func FromEngineTypes(engineTypes []engine.QuestionType) []Type {
 	packTypes := make([]Type, 0, len(engineTypes))
@@ -67,6 +97,7 @@ type Question struct {
 	Type       Type
 	Prompt     string
 	Answer     Answer
+	Notes      engine.Notes
 }
 
 func (q Question) Validate() error {
@@ -87,8 +118,10 @@ func (q Question) Validate() error {
 
 func (q Question) ToEngine() engine.Question {
 	base := engine.BaseQuestion{
-		ID:     q.ID,
-		Prompt: q.Prompt,
+		ID:         q.ID,
+		Prompt:     q.Prompt,
+		Notes:      q.Notes,
+		Difficulty: q.Difficulty,
 	}
 
 	switch q.Type {
@@ -124,6 +157,24 @@ func (q Question) ToEngine() engine.Question {
 			Keywords:       ans.Keywords,
 		}
 
+	case TypeShort:
+		ans := q.Answer.(ShortAnswer)
+		return engine.ShortAnswerQuestion{
+			BaseQuestion:  base,
+			Accepted:      ans.Accepted,
+			CaseSensitive: ans.CaseSensitive,
+			Patterns:      ans.Patterns,
+			Number:        ans.Number,
+			Tolerance:     ans.Tolerance,
+		}
+
+	case TypeOrder:
+		ans := q.Answer.(OrderAnswer)
+		return engine.OrderingQuestion{
+			BaseQuestion: base,
+			Items:        ans.Items,
+		}
+
 	default:
 		return nil
 	}
@@ -183,7 +234,23 @@ func (BoolAnswer) isAnswer() {}
 
 type TextAnswer struct {
 	Expected string
-	Keywords []string
+	Keywords []engine.Keyword
 }
 
 func (TextAnswer) isAnswer() {}
+
+type ShortAnswer struct {
+	Accepted      []string
+	CaseSensitive bool
+	Patterns      []string
+	Number        *float64
+	Tolerance     float64
+}
+
+func (ShortAnswer) isAnswer() {}
+
+type OrderAnswer struct {
+	Items []string // in the correct order
+}
+
+func (OrderAnswer) isAnswer() {}
@@ -3,7 +3,9 @@ package pack
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"time"
 
@@ -11,22 +13,47 @@ import (
 )
 
 type Raw struct {
+	SchemaVersion int `json:"schema_version"`
+
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
 	Role      string    `json:"role"`
-	Version   string    `json:"version"`
+	Version   string    `json:"version,omitempty"`
 	Creator   string    `json:"creator"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
 
 	Categories map[string]RawCategory `json:"categories"` // category name -> questions
+
+	// Set by Unpack, the schema the file was written in, the keys
+	// it had that nothing reads and where everything is in it
+	readVersion int
+	unknown     []Issue
+	positions   map[string]Position // JSON path -> position
 }
 
 type RawCategory struct {
-	Choice         []RawChoiceQuestion `json:"choice"`
-	MultipleChoice []RawMultiQuestion  `json:"multiple_choice"`
-	Bool      []RawBoolQuestion   `json:"bool"`
-	TextEntry      []RawTextQuestion   `json:"text_entry"`
+	Choice         []RawChoiceQuestion `json:"choice,omitempty"`
+	MultipleChoice []RawMultiQuestion  `json:"multiple_choice,omitempty"`
+	Bool      []RawBoolQuestion   `json:"bool,omitempty"`
+	TextEntry      []RawTextQuestion   `json:"text_entry,omitempty"`
+	ShortAnswer    []RawShortQuestion  `json:"short_answer,omitempty"`
+	Ordering       []RawOrderQuestion  `json:"ordering,omitempty"`
+}
+
+// RawNotes is the optional study material any question can carry
+type RawNotes struct {
+	Explanation string   `json:"explanation,omitempty"`
+	References  []string `json:"references,omitempty"` // URLs or doc titles
+	Hint        string   `json:"hint,omitempty"`
+}
+
+func (n RawNotes) ToEngine() engine.Notes {
+	return engine.Notes{
+		Explanation: n.Explanation,
+		References:  n.References,
+		Hint:        n.Hint,
+	}
 }
 
 type RawChoiceQuestion struct {
@@ -35,6 +62,7 @@ type RawChoiceQuestion struct {
 	Prompt     string   `json:"prompt"`
 	Options    []string `json:"options"`
 	Answer     int      `json:"answer"`
+	RawNotes
 }
 
 type RawMultiQuestion struct {
@@ -43,6 +71,7 @@ type RawMultiQuestion struct {
 	Prompt     string   `json:"prompt"`
 	Options    []string `json:"options"`
 	Answer     []int    `json:"answer"`
+	RawNotes
 }
 
 type RawBoolQuestion struct {
@@ -50,18 +79,107 @@ type RawBoolQuestion struct {
 	Difficulty string `json:"difficulty"`
 	Prompt     string `json:"prompt"`
 	Answer     bool   `json:"answer"`
+	RawNotes
 }
 
 type RawTextQuestion struct {
+	ID         string       `json:"id"`
+	Difficulty string       `json:"difficulty"`
+	Prompt     string       `json:"prompt"`
+	Expected   string       `json:"expected,omitempty"` // expected_answer before schema 2
+	Keywords   []RawKeyword `json:"keywords,omitempty"`
+	RawNotes
+}
+
+// RawKeyword is either a plain string or an object
+// with synonyms and a weight:
+//
+//	"keywords": ["goroutine", {"term": "channel", "synonyms": ["chan"], "weight": 2}]
+type RawKeyword struct {
+	Term     string   `json:"term"`
+	Synonyms []string `json:"synonyms,omitempty"`
+	Weight   float32  `json:"weight,omitempty"` // 0 counts as 1
+}
+
+func (k *RawKeyword) UnmarshalJSON(data []byte) error {
+	var term string
+	if err := json.Unmarshal(data, &term); err == nil {
+		*k = RawKeyword{Term: term}
+		return nil
+	}
+
+	type plain RawKeyword
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("keyword must be a string or an object: %w", err)
+	}
+	*k = RawKeyword(p)
+	return nil
+}
+
+// MarshalJSON writes keywords without extras back as plain strings
+func (k RawKeyword) MarshalJSON() ([]byte, error) {
+	if len(k.Synonyms) == 0 && k.Weight == 0 {
+		return json.Marshal(k.Term)
+	}
+
+	type plain RawKeyword
+	return json.Marshal(plain(k))
+}
+
+// RawShortQuestion is answered in a word or a number, it needs at
+// least one of accepted, patterns or number
+type RawShortQuestion struct {
+	ID            string   `json:"id"`
+	Difficulty    string   `json:"difficulty"`
+	Prompt        string   `json:"prompt"`
+	Accepted      []string `json:"accepted,omitempty"`
+	CaseSensitive bool     `json:"case_sensitive,omitempty"`
+	Patterns      []string `json:"patterns,omitempty"` // regular expressions matched against the whole answer
+	Number        *float64 `json:"number,omitempty"`
+	Tolerance     float64  `json:"tolerance,omitempty"` // how far off a numeric answer can be
+	RawNotes
+}
+
+// RawOrderQuestion lists its items in the correct order,
+// they are shuffled when the question is asked
+type RawOrderQuestion struct {
 	ID         string   `json:"id"`
 	Difficulty string   `json:"difficulty"`
 	Prompt     string   `json:"prompt"`
-	Expected   string   `json:"expected"` // Renamed this, was expected_answer before
-	Keywords   []string `json:"keywords"`
+	Items      []string `json:"items"`
+	RawNotes
+}
+
+func (k RawKeyword) ToEngine() engine.Keyword {
+	return engine.Keyword{
+		Term:     k.Term,
+		Synonyms: k.Synonyms,
+		Weight:   k.Weight,
+	}
+}
+
+func keywordsToEngine(raw []RawKeyword) []engine.Keyword {
+	keywords := make([]engine.Keyword, len(raw))
+	for i, k := range raw {
+		keywords[i] = k.ToEngine()
+	}
+	return keywords
 }
 
+// Save writes the pack in the format its extension says,
+// so a pack is saved in the format it was written in. A file
+// that's already there is rewritten, see Rewrite
 func (r *Raw) Save(filepath string) error {
-	data, err := json.MarshalIndent(r, "", "  ")
+	r.SchemaVersion = SchemaVersion
+
+	orig, err := os.ReadFile(filepath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read pack: %w", err)
+	}
+
+	format, _ := FormatOf(filepath)
+	data, err := r.Rewrite(orig, format)
 	if err != nil {
 		return fmt.Errorf("failed to marshal pack: %w", err)
 	}
@@ -79,12 +197,22 @@ func (r *Raw) Save(filepath string) error {
 func (r *Raw) Verify() Report {
 	var report Report
 
+	if r.readVersion != 0 && r.readVersion < SchemaVersion {
+		report.Warnings = append(report.Warnings, NewWarning(
+			IssueOutdatedSchema,
+			fmt.Sprintf("Pack uses schema %d, repair upgrades it to %d", r.readVersion, SchemaVersion),
+			"schema_version",
+			r.ID,
+		))
+	}
+	report.Warnings = append(report.Warnings, r.unknown...)
+
 	// Verify pack-level fields
 	if r.ID == "" {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingID,
 			"Missing pack ID",
-			"pack",
+			"id",
 			"",
 		))
 	}
@@ -93,7 +221,7 @@ func (r *Raw) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing pack name",
-			"pack",
+			"name",
 			r.ID,
 		))
 	}
@@ -102,7 +230,7 @@ func (r *Raw) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing pack role",
-			"pack",
+			"role",
 			r.ID,
 		))
 	}
@@ -111,7 +239,7 @@ func (r *Raw) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing pack creator",
-			"pack",
+			"creator",
 			r.ID,
 		))
 	}
@@ -120,7 +248,7 @@ func (r *Raw) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Pack has no categories",
-			"pack",
+			"categories",
 			r.ID,
 		))
 	}
@@ -132,7 +260,8 @@ func (r *Raw) Verify() Report {
 	for categoryName, category := range r.Categories {
 		// Verify Choice questions
 		for i, q := range category.Choice {
-			qReport := q.Verify()
+			path := questionPath(categoryName, "choice", i)
+			qReport := q.Verify(path)
 			report.merge(qReport)
 
 			if q.ID != "" {
@@ -140,7 +269,7 @@ func (r *Raw) Verify() Report {
 					report.Errors = append(report.Errors, NewError(
 						IssueDuplicateID,
 						fmt.Sprintf("Duplicate question ID: %s", q.ID),
-						fmt.Sprintf("categories.%s.choice[%d]", categoryName, i),
+						path+".id",
 						q.ID,
 					))
 				}
@@ -150,7 +279,8 @@ func (r *Raw) Verify() Report {
 
 		// Verify Multi questions
 		for i, q := range category.MultipleChoice {
-			qReport := q.Verify()
+			path := questionPath(categoryName, "multiple_choice", i)
+			qReport := q.Verify(path)
 			report.merge(qReport)
 
 			if q.ID != "" {
@@ -158,7 +288,7 @@ func (r *Raw) Verify() Report {
 					report.Errors = append(report.Errors, NewError(
 						IssueDuplicateID,
 						fmt.Sprintf("Duplicate question ID: %s", q.ID),
-						fmt.Sprintf("categories.%s.multiple_choice[%d]", categoryName, i),
+						path+".id",
 						q.ID,
 					))
 				}
@@ -168,7 +298,8 @@ func (r *Raw) Verify() Report {
 
 		// Verify Bool questions
 		for i, q := range category.Bool {
-			qReport := q.Verify()
+			path := questionPath(categoryName, "bool", i)
+			qReport := q.Verify(path)
 			report.merge(qReport)
 
 			if q.ID != "" {
@@ -176,7 +307,7 @@ func (r *Raw) Verify() Report {
 					report.Errors = append(report.Errors, NewError(
 						IssueDuplicateID,
 						fmt.Sprintf("Duplicate question ID: %s", q.ID),
-						fmt.Sprintf("categories.%s.bool[%d]", categoryName, i),
+						path+".id",
 						q.ID,
 					))
 				}
@@ -186,7 +317,46 @@ func (r *Raw) Verify() Report {
 
 		// Verify Text questions
 		for i, q := range category.TextEntry {
-			qReport := q.Verify()
+			path := questionPath(categoryName, "text_entry", i)
+			qReport := q.Verify(path)
+			report.merge(qReport)
+
+			if q.ID != "" {
+				if seenIDs[q.ID] {
+					report.Errors = append(report.Errors, NewError(
+						IssueDuplicateID,
+						fmt.Sprintf("Duplicate question ID: %s", q.ID),
+						path+".id",
+						q.ID,
+					))
+				}
+				seenIDs[q.ID] = true
+			}
+		}
+
+		// Verify Short answer questions
+		for i, q := range category.ShortAnswer {
+			path := questionPath(categoryName, "short_answer", i)
+			qReport := q.Verify(path)
+			report.merge(qReport)
+
+			if q.ID != "" {
+				if seenIDs[q.ID] {
+					report.Errors = append(report.Errors, NewError(
+						IssueDuplicateID,
+						fmt.Sprintf("Duplicate question ID: %s", q.ID),
+						path+".id",
+						q.ID,
+					))
+				}
+				seenIDs[q.ID] = true
+			}
+		}
+
+		// Verify Ordering questions
+		for i, q := range category.Ordering {
+			path := questionPath(categoryName, "ordering", i)
+			qReport := q.Verify(path)
 			report.merge(qReport)
 
 			if q.ID != "" {
@@ -194,7 +364,7 @@ func (r *Raw) Verify() Report {
 					report.Errors = append(report.Errors, NewError(
 						IssueDuplicateID,
 						fmt.Sprintf("Duplicate question ID: %s", q.ID),
-						fmt.Sprintf("categories.%s.text_entry[%d]", categoryName, i),
+						path+".id",
 						q.ID,
 					))
 				}
@@ -203,7 +373,7 @@ func (r *Raw) Verify() Report {
 		}
 	}
 
-	return report
+	return r.locate(report)
 }
 
 /** REPAIR **/
@@ -212,6 +382,12 @@ func (r *Raw) Verify() Report {
 func (r *Raw) Repair() Report {
 	var report Report
 
+	// Unpack already migrated it, saving is what's left
+	if r.readVersion != 0 && r.readVersion < SchemaVersion {
+		r.readVersion = SchemaVersion
+		report.Repaired++
+	}
+
 	// Generate pack ID if missing
 	if r.ID == "" {
 		packHash := NewPackHash(r.Name, r.Creator, r.Version)
@@ -288,6 +464,40 @@ func (r *Raw) Repair() Report {
 				report.Repaired++
 			}
 		}
+
+		// Repair Short answer questions
+		for i := range category.ShortAnswer {
+			q := &category.ShortAnswer[i]
+			if q.ID == "" {
+				qHash := NewQuestionHash(
+					r.ID,
+					q.Prompt,
+					q.Difficulty,
+					categoryName,
+					TypeShort,
+					i,
+				)
+				q.ID = qHash.ID()
+				report.Repaired++
+			}
+		}
+
+		// Repair Ordering questions
+		for i := range category.Ordering {
+			q := &category.Ordering[i]
+			if q.ID == "" {
+				qHash := NewQuestionHash(
+					r.ID,
+					q.Prompt,
+					q.Difficulty,
+					categoryName,
+					TypeOrder,
+					i,
+				)
+				q.ID = qHash.ID()
+				report.Repaired++
+			}
+		}
 	}
 
 	return report
@@ -306,6 +516,7 @@ func (r *Raw) ToDomain(filepath string) *Pack {
 				Category:   categoryName,
 				Type:       TypeChoice,
 				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
 				Answer: ChoiceAnswer{
 					Options: rawQ.Options,
 					Correct: rawQ.Answer,
@@ -321,6 +532,7 @@ func (r *Raw) ToDomain(filepath string) *Pack {
 				Category:   categoryName,
 				Type:       TypeMulti,
 				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
 				Answer: MultiAnswer{
 					Options: rawQ.Options,
 					Correct: rawQ.Answer,
@@ -336,6 +548,7 @@ func (r *Raw) ToDomain(filepath string) *Pack {
 				Category:   categoryName,
 				Type:       TypeBool,
 				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
 				Answer: BoolAnswer{
 					Correct: rawQ.Answer,
 				},
@@ -350,9 +563,44 @@ func (r *Raw) ToDomain(filepath string) *Pack {
 				Category:   categoryName,
 				Type:       TypeText,
 				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
 				Answer: TextAnswer{
 					Expected: rawQ.Expected,
-					Keywords: rawQ.Keywords,
+					Keywords: keywordsToEngine(rawQ.Keywords),
+				},
+			})
+		}
+
+		// Convert Short answer questions
+		for _, rawQ := range category.ShortAnswer {
+			questions = append(questions, Question{
+				ID:         rawQ.ID,
+				Difficulty: engine.ParseDifficulty(rawQ.Difficulty),
+				Category:   categoryName,
+				Type:       TypeShort,
+				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
+				Answer: ShortAnswer{
+					Accepted:      rawQ.Accepted,
+					CaseSensitive: rawQ.CaseSensitive,
+					Patterns:      rawQ.Patterns,
+					Number:        rawQ.Number,
+					Tolerance:     rawQ.Tolerance,
+				},
+			})
+		}
+
+		// Convert Ordering questions
+		for _, rawQ := range category.Ordering {
+			questions = append(questions, Question{
+				ID:         rawQ.ID,
+				Difficulty: engine.ParseDifficulty(rawQ.Difficulty),
+				Category:   categoryName,
+				Type:       TypeOrder,
+				Prompt:     rawQ.Prompt,
+				Notes:      rawQ.RawNotes.ToEngine(),
+				Answer: OrderAnswer{
+					Items: rawQ.Items,
 				},
 			})
 		}
@@ -1,5 +1,7 @@
 package pack
 
+import "fmt"
+
 type Report struct {
 	Repaired int
 	Warnings []Issue
@@ -21,6 +23,9 @@ type Issue struct {
 
 	Path string
 
+	// Where it is in the pack file, zero when the pack wasn't read from one
+	Pos Position
+
 	// Stable identifier for the entity involved (question / pack)
 	Ref string
 
@@ -45,6 +50,8 @@ const (
 	IssueInvalidDifficulty
 	IssueInvalidAnswer
 	IssueInvalidFormat
+	IssueUnknownKey
+	IssueOutdatedSchema
 )
 
 func NewIssue(
@@ -70,3 +77,55 @@ func NewError(kind IssueKind, message, path, ref string) Issue {
 func NewWarning(kind IssueKind, message, path, ref string) Issue {
 	return NewIssue(IssueWarning, kind, message, path, ref)
 }
+
+func (r Report) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+func (l IssueLevel) String() string {
+	switch l {
+	case IssueWarning:
+		return "warning"
+	case IssueError:
+		return "error"
+	default:
+		return ""
+	}
+}
+
+func (k IssueKind) String() string {
+	switch k {
+	case IssueMissingID:
+		return "missing id"
+	case IssueDuplicateID:
+		return "duplicate id"
+	case IssueMissingField:
+		return "missing field"
+	case IssueInvalidDifficulty:
+		return "invalid difficulty"
+	case IssueInvalidAnswer:
+		return "invalid answer"
+	case IssueInvalidFormat:
+		return "invalid format"
+	case IssueUnknownKey:
+		return "unknown key"
+	case IssueOutdatedSchema:
+		return "outdated schema"
+	default:
+		return "unknown"
+	}
+}
+
+func (i Issue) String() string {
+	s := fmt.Sprintf("%s: [%s] %s", i.Level, i.Kind, i.Message)
+	if i.Path != "" {
+		s += " (" + i.Path + ")"
+	}
+	if i.Ref != "" {
+		s += " ref=" + i.Ref
+	}
+	if i.Pos.IsValid() {
+		s += " at " + i.Pos.String()
+	}
+	return s
+}
@@ -0,0 +1,237 @@
+package pack
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the pack layout this version of ace writes. Packs
+// without a schema_version predate it and count as version 1
+const SchemaVersion = 2
+
+// A migration upgrades a decoded pack document from one
+// schema version to the next, in place
+type migration struct {
+	from  int
+	apply func(doc map[string]any)
+}
+
+// Every step from version 1 up to SchemaVersion, in order
+var migrations = []migration{
+	{from: 1, apply: migrateCategoryKeys},
+}
+
+// Question lists of version 1 categories and what they're called now
+var legacyCategoryKeys = map[string]string{
+	"multipleChoice": "multiple_choice",
+	"Bool":           "bool",
+	"textEntry":      "text_entry",
+}
+
+// Version 1 packs used camel case question lists and
+// called the expected answer of text questions expected_answer
+func migrateCategoryKeys(doc map[string]any) {
+	categories, _ := doc["categories"].(map[string]any)
+	for _, c := range categories {
+		category, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for old, new := range legacyCategoryKeys {
+			renameKey(category, old, new)
+		}
+
+		questions, _ := category["text_entry"].([]any)
+		for _, q := range questions {
+			if question, ok := q.(map[string]any); ok {
+				renameKey(question, "expected_answer", "expected")
+			}
+		}
+	}
+}
+
+// migratedKey is what key, found in the object at path,
+// is called once a version 1 pack is migrated
+func migratedKey(path, key string) string {
+	// A category, categories.go
+	if parentPath(path) == "categories" {
+		if new, ok := legacyCategoryKeys[key]; ok {
+			return new
+		}
+		return key
+	}
+
+	// A text question, categories.go.text_entry[0]
+	list := parentPath(path)
+	if key == "expected_answer" && strings.HasSuffix(path, "]") && strings.HasSuffix(list, ".text_entry") &&
+		parentPath(parentPath(list)) == "categories" {
+		return "expected"
+	}
+	return key
+}
+
+// renameKey moves old to new, question lists under both are joined
+func renameKey(m map[string]any, old, new string) {
+	value, ok := m[old]
+	if !ok {
+		return
+	}
+	delete(m, old)
+
+	oldList, oldIsList := value.([]any)
+	newList, newIsList := m[new].([]any)
+	if oldIsList && newIsList {
+		m[new] = append(newList, oldList...)
+		return
+	}
+
+	if _, exists := m[new]; !exists {
+		m[new] = value
+	}
+}
+
+// migrate upgrades doc to SchemaVersion and returns the version it started at
+func migrate(doc map[string]any) (int, error) {
+	version := 1
+	if v, ok := doc["schema_version"]; ok {
+		n, ok := v.(json.Number)
+		if !ok {
+			return 0, fmt.Errorf("%w: schema_version must be a number", ErrInvalidData)
+		}
+		i, err := n.Int64()
+		if err != nil || i < 1 {
+			return 0, fmt.Errorf("%w: schema_version %s", ErrInvalidData, n)
+		}
+		version = int(i)
+	}
+
+	if version > SchemaVersion {
+		return 0, fmt.Errorf("%w: schema_version %d is newer than %d, update ace", ErrInvalidData, version, SchemaVersion)
+	}
+
+	from := version
+	for _, m := range migrations {
+		if m.from == version {
+			m.apply(doc)
+			version++
+		}
+	}
+	doc["schema_version"] = SchemaVersion
+
+	return from, nil
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// unknownKeys walks a decoded document alongside the type it decodes
+// into and warns about every key that would be dropped. Keys that only
+// differ in case are reported too, the standard decoder takes them
+// silently but a pack shouldn't rely on it
+func unknownKeys(value any, t reflect.Type, path string) []Issue {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var issues []Issue
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok || t == timeType {
+			// Types like RawKeyword also take a plain string
+			return nil
+		}
+
+		fields := jsonFields(t)
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			field, known := fields[key]
+			if known {
+				issues = append(issues, unknownKeys(obj[key], field, joinPath(path, key))...)
+				continue
+			}
+
+			message := fmt.Sprintf("Unknown key %q is ignored", key)
+			for name := range fields {
+				if strings.EqualFold(name, key) {
+					message = fmt.Sprintf("Key %q should be written %q", key, name)
+					break
+				}
+			}
+			issues = append(issues, NewWarning(IssueUnknownKey, message, joinPath(path, key), ""))
+		}
+
+	case reflect.Slice:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range list {
+			issues = append(issues, unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, key := range slices.Sorted(maps.Keys(obj)) {
+			issues = append(issues, unknownKeys(obj[key], t.Elem(), joinPath(path, key))...)
+		}
+	}
+
+	return issues
+}
+
+// jsonFields maps the JSON keys of struct t to their types,
+// fields of embedded structs like RawNotes included
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			maps.Copy(fields, jsonFields(f.Type))
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
+
+// joinPath adds key to a JSON path. Keys that would be misread in one,
+// with dots or brackets in them, are quoted: categories["node.js"]
+func joinPath(path, key string) string {
+	if key == "" || strings.ContainsAny(key, `.[]"`) {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// questionPath is the JSON path of the i-th question of a list in category
+func questionPath(category, list string, i int) string {
+	return fmt.Sprintf("%s[%d]", joinPath(joinPath("categories", category), list), i)
+}
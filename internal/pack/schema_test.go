@@ -0,0 +1,281 @@
+package pack
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		version int // the version read, 0 when it fails
+		err     error
+
+		multi, bools, texts int
+		expected            string // of the first text question
+	}{
+		{
+			name: "version 1",
+			doc: `{"id": "p", "categories": {"go": {
+				"multipleChoice": [{"id": "m", "options": ["a", "b"], "answer": [0]}],
+				"Bool": [{"id": "b", "answer": true}],
+				"textEntry": [{"id": "t", "expected_answer": "A thread"}]}}}`,
+			version: 1,
+			multi:   1, bools: 1, texts: 1,
+			expected: "A thread",
+		},
+		{
+			name: "old and new lists are joined",
+			doc: `{"schema_version": 1, "id": "p", "categories": {"go": {
+				"textEntry": [{"id": "old", "expected_answer": "old"}],
+				"text_entry": [{"id": "new", "expected": "new"}]}}}`,
+			version:  1,
+			texts:    2,
+			expected: "new",
+		},
+		{
+			name:    "current",
+			doc:     `{"schema_version": 2, "id": "p", "categories": {"go": {"text_entry": [{"id": "t", "expected": "A thread"}]}}}`,
+			version: 2,
+			texts:   1, expected: "A thread",
+		},
+		{name: "newer than ace", doc: `{"schema_version": 3, "id": "p"}`, err: ErrInvalidData},
+		{name: "not a number", doc: `{"schema_version": "2", "id": "p"}`, err: ErrInvalidData},
+		{name: "zero", doc: `{"schema_version": 0, "id": "p"}`, err: ErrInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := Unpack([]byte(tt.doc), FormatJSON)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Unpack() error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unpack() error = %v", err)
+			}
+
+			if raw.readVersion != tt.version || raw.SchemaVersion != SchemaVersion {
+				t.Errorf("read version %d, schema %d, want %d, %d", raw.readVersion, raw.SchemaVersion, tt.version, SchemaVersion)
+			}
+			if len(raw.unknown) > 0 {
+				t.Errorf("migrated keys reported unknown: %v", raw.unknown)
+			}
+
+			c := raw.Categories["go"]
+			if len(c.MultipleChoice) != tt.multi || len(c.Bool) != tt.bools || len(c.TextEntry) != tt.texts {
+				t.Errorf("got %d multi, %d bool, %d text, want %d, %d, %d",
+					len(c.MultipleChoice), len(c.Bool), len(c.TextEntry), tt.multi, tt.bools, tt.texts)
+			}
+			if tt.texts > 0 && c.TextEntry[0].Expected != tt.expected {
+				t.Errorf("expected = %q, want %q", c.TextEntry[0].Expected, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	doc := `{
+  "schema_version": 2,
+  "id": "p",
+  "author": "me",
+  "categories": {
+    "go": {
+      "choice": [{"id": "c", "Prompt": "?", "options": ["a"], "answer": 0, "hint": "h", "note": "n"}],
+      "text_entry": [{"id": "t", "keywords": ["plain", {"term": "t", "synonym": ["s"]}]}],
+      "flashcards": []
+    }
+  },
+  "created_at": "2024-01-02T03:04:05Z"
+}`
+
+	raw, err := Unpack([]byte(doc), FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"author":                                          `Unknown key "author" is ignored`,
+		"categories.go.choice[0].Prompt":                  `Key "Prompt" should be written "prompt"`,
+		"categories.go.choice[0].note":                    `Unknown key "note" is ignored`,
+		"categories.go.flashcards":                        `Unknown key "flashcards" is ignored`,
+		"categories.go.text_entry[0].keywords[1].synonym": `Unknown key "synonym" is ignored`,
+	}
+
+	var paths []string
+	for _, issue := range raw.unknown {
+		paths = append(paths, issue.Path)
+		if msg, ok := want[issue.Path]; !ok || issue.Message != msg {
+			t.Errorf("%s: %q, want %q", issue.Path, issue.Message, msg)
+		}
+	}
+	if len(paths) != len(want) {
+		t.Errorf("unknown keys at %v, want %d of them", paths, len(want))
+	}
+
+	// Each one points at its key in the file
+	for _, issue := range raw.Verify().Warnings {
+		if issue.Kind != IssueUnknownKey {
+			continue
+		}
+		key := issue.Path[strings.LastIndexAny(issue.Path, ".")+1:]
+		line := strings.Split(doc, "\n")[issue.Pos.Line-1]
+		if !strings.Contains(line, `"`+key+`"`) {
+			t.Errorf("%s located at line %d: %s", issue.Path, issue.Pos.Line, line)
+		}
+	}
+}
+
+func TestVerifyLocatesEveryIssue(t *testing.T) {
+	doc := `schema_version: 2
+id: pack_paths
+name: Paths
+role: backend
+creator: me
+categories:
+  go:
+    choice:
+      - id: c
+        difficulty: junior
+        prompt: Pick one
+        options: [a, b]
+        answer: 5
+    text_entry:
+      - id: t
+        difficulty: expert
+        prompt: Explain
+        keywords:
+          - term: ""
+            weight: -1
+    ordering:
+      - id: c
+        difficulty: senior
+        prompt: Order
+        items: [one, one]
+`
+
+	raw, err := Unpack([]byte(doc), FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := raw.Verify()
+
+	want := map[string]int{ // path -> line
+		"categories.go.choice[0].answer":                 13,
+		"categories.go.text_entry[0].difficulty":         16,
+		"categories.go.text_entry[0].keywords[0].term":   19,
+		"categories.go.text_entry[0].keywords[0].weight": 20,
+		"categories.go.ordering[0].id":                   22,
+		"categories.go.ordering[0].items[1]":             25,
+		"categories.go.ordering[0].explanation":          22,
+	}
+
+	var got []string
+	for _, issue := range slices.Concat(report.Errors, report.Warnings) {
+		got = append(got, issue.Path)
+		line, ok := want[issue.Path]
+		if !ok {
+			t.Errorf("unexpected issue at %s: %s", issue.Path, issue.Message)
+			continue
+		}
+		if issue.Pos.Line != line {
+			t.Errorf("%s located at line %d, want %d", issue.Path, issue.Pos.Line, line)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("issues at %v, want %d of them", got, len(want))
+	}
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"categories", "go", "choice"}, "categories.go.choice"},
+		{[]string{"categories", "node.js", "choice"}, `categories["node.js"].choice`},
+		{[]string{"categories", `a[0]"b`}, `categories["a[0]\"b"]`},
+		{[]string{"v1.2", "id"}, `["v1.2"].id`},
+	}
+
+	for _, tt := range tests {
+		path := ""
+		for _, key := range tt.keys {
+			path = joinPath(path, key)
+		}
+		if path != tt.want {
+			t.Errorf("joinPath(%q) = %s, want %s", tt.keys, path, tt.want)
+		}
+
+		// Walking back up drops a key at a time
+		for i := len(tt.keys) - 1; i > 0; i-- {
+			want := ""
+			for _, key := range tt.keys[:i] {
+				want = joinPath(want, key)
+			}
+			if path = parentPath(path); path != want {
+				t.Errorf("parentPath() = %s, want %s", path, want)
+			}
+		}
+		if path = parentPath(path); path != "" {
+			t.Errorf("parentPath() of the first key = %s, want nothing", path)
+		}
+	}
+}
+
+func TestDottedCategory(t *testing.T) {
+	doc := `{
+  "id": "p",
+  "name": "P",
+  "role": "r",
+  "creator": "c",
+  "categories": {
+    "node.js": {
+      "textEntry": [
+        {
+          "id": "t",
+          "difficulty": "junior",
+          "expected_answer": "An event loop",
+          "note": "n"
+        }
+      ]
+    }
+  }
+}`
+
+	raw, err := Unpack([]byte(doc), FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := raw.Categories["node.js"].TextEntry; len(q) != 1 || q[0].Expected != "An event loop" {
+		t.Fatalf("migrated to %+v, want one text question expecting An event loop", q)
+	}
+
+	report := raw.Verify()
+	want := map[string]int{ // path -> line
+		"schema_version": 0, // not in the file
+		`categories["node.js"].text_entry[0].prompt`: 9,
+		`categories["node.js"].text_entry[0].note`:   13,
+	}
+
+	var got []string
+	for _, issue := range slices.Concat(report.Errors, report.Warnings) {
+		got = append(got, issue.Path)
+		line, ok := want[issue.Path]
+		if !ok {
+			t.Errorf("unexpected issue at %s: %s", issue.Path, issue.Message)
+			continue
+		}
+		if issue.Pos.Line != line {
+			t.Errorf("%s located at line %d, want %d", issue.Path, issue.Pos.Line, line)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("issues at %v, want %d of them", got, len(want))
+	}
+}
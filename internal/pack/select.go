@@ -0,0 +1,98 @@
+package pack
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+// SelectOptions controls which looked up questions make it into a session
+type SelectOptions struct {
+	Categories engine.CategorySet // empty = all
+	Randomize  bool
+	Count      int   // 0 = all
+	Seed       int64 // same seed, same order
+
+	// Recently seen questions go to the back of
+	// the line and only fill in when nothing else is left
+	Recent map[string]bool
+}
+
+// Select narrows the IDs from Lookup.GetQuestionIDs down to the ones
+// a session plays. It filters by category, shuffles, puts recently seen
+// questions last and, when it has to cut down to Count, keeps the mix of
+// question types as even as it can
+func Select(ids []string, details DetailIndex, opt SelectOptions) []string {
+	selected := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if len(opt.Categories) > 0 && !slices.Contains(opt.Categories, engine.Category(details[id].Category)) {
+			continue
+		}
+		selected = append(selected, id)
+	}
+
+	if opt.Randomize {
+		rng := rand.New(rand.NewPCG(uint64(opt.Seed), uint64(opt.Seed)))
+		rng.Shuffle(len(selected), func(i, j int) {
+			selected[i], selected[j] = selected[j], selected[i]
+		})
+	}
+
+	var fresh, seen []string
+	for _, id := range selected {
+		if opt.Recent[id] {
+			seen = append(seen, id)
+		} else {
+			fresh = append(fresh, id)
+		}
+	}
+
+	if opt.Count <= 0 || opt.Count >= len(selected) {
+		return append(fresh, seen...)
+	}
+
+	kept := balance(fresh, details, min(opt.Count, len(fresh)))
+	if len(kept) < opt.Count {
+		kept = append(kept, balance(seen, details, opt.Count-len(kept))...)
+	}
+
+	return kept
+}
+
+// balance keeps count IDs, taking them in turn from each question type so
+// one type can't crowd out the rest. The kept IDs stay in their original order
+func balance(ids []string, details DetailIndex, count int) []string {
+	var order []Type
+	byType := make(map[Type][]string)
+	for _, id := range ids {
+		t := details[id].Type
+		if _, ok := byType[t]; !ok {
+			order = append(order, t)
+		}
+		byType[t] = append(byType[t], id)
+	}
+
+	keep := make(map[string]bool, count)
+	for len(keep) < count {
+		for _, t := range order {
+			if len(keep) == count {
+				break
+			}
+			if len(byType[t]) == 0 {
+				continue
+			}
+			keep[byType[t][0]] = true
+			byType[t] = byType[t][1:]
+		}
+	}
+
+	balanced := make([]string, 0, count)
+	for _, id := range ids {
+		if keep[id] {
+			balanced = append(balanced, id)
+		}
+	}
+
+	return balanced
+}
@@ -0,0 +1,67 @@
+package pack
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+func testDetails() ([]string, DetailIndex) {
+	details := DetailIndex{
+		"c1": {Category: "go", Type: TypeChoice},
+		"c2": {Category: "go", Type: TypeChoice},
+		"c3": {Category: "go", Type: TypeChoice},
+		"c4": {Category: "go", Type: TypeChoice},
+		"b1": {Category: "go", Type: TypeBool},
+		"t1": {Category: "sql", Type: TypeText},
+		"t2": {Category: "sql", Type: TypeText},
+	}
+	return []string{"c1", "c2", "c3", "c4", "b1", "t1", "t2"}, details
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  SelectOptions
+		want []string
+	}{
+		{"everything in order", SelectOptions{}, []string{"c1", "c2", "c3", "c4", "b1", "t1", "t2"}},
+		{"by category", SelectOptions{Categories: engine.CategorySet{"sql"}}, []string{"t1", "t2"}},
+		{"recent go last", SelectOptions{Recent: map[string]bool{"c1": true, "b1": true}}, []string{"c2", "c3", "c4", "t1", "t2", "c1", "b1"}},
+		{"count keeps the types even", SelectOptions{Count: 3}, []string{"c1", "b1", "t1"}},
+		{"count takes turns", SelectOptions{Count: 5}, []string{"c1", "c2", "b1", "t1", "t2"}},
+		{"recent only fill in", SelectOptions{Count: 6, Recent: map[string]bool{"c1": true, "c2": true}}, []string{"c3", "c4", "b1", "t1", "t2", "c1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, details := testDetails()
+			if got := Select(ids, details, tt.opt); !slices.Equal(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSeed(t *testing.T) {
+	ids, details := testDetails()
+	selectWith := func(seed int64) []string {
+		return Select(slices.Clone(ids), details, SelectOptions{Randomize: true, Seed: seed})
+	}
+
+	first := selectWith(42)
+	for range 5 {
+		if again := selectWith(42); !slices.Equal(again, first) {
+			t.Fatalf("seed 42 selected %v, then %v", first, again)
+		}
+	}
+
+	// Some other seed has to shuffle differently
+	for seed := int64(1); seed <= 20; seed++ {
+		if !slices.Equal(selectWith(seed), first) {
+			return
+		}
+	}
+	t.Errorf("every seed selected %v", first)
+}
@@ -2,6 +2,8 @@ package pack
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/cheezecakee/ace/internal/engine"
 )
@@ -24,14 +26,14 @@ type VerifyRepair interface {
 	Repair
 }
 
-func (q *RawChoiceQuestion) Verify() Report {
+func (q *RawChoiceQuestion) Verify(path string) Report {
 	var report Report
 
 	if q.ID == "" {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingID,
 			"Missing question ID",
-			"choice",
+			path,
 			"",
 		))
 	}
@@ -40,7 +42,7 @@ func (q *RawChoiceQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing prompt",
-			"choice",
+			path+".prompt",
 			q.ID,
 		))
 	}
@@ -49,7 +51,7 @@ func (q *RawChoiceQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueInvalidDifficulty,
 			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
-			"choice",
+			path+".difficulty",
 			q.ID,
 		))
 	}
@@ -58,7 +60,7 @@ func (q *RawChoiceQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"No options provided",
-			"choice",
+			path+".options",
 			q.ID,
 		))
 	}
@@ -67,11 +69,13 @@ func (q *RawChoiceQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueInvalidAnswer,
 			fmt.Sprintf("Answer index %d out of range (0-%d)", q.Answer, len(q.Options)-1),
-			"choice",
+			path+".answer",
 			q.ID,
 		))
 	}
 
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
 	return report
 }
 
@@ -81,14 +85,14 @@ func (q *RawChoiceQuestion) Repair(issue Issue) Report {
 	return report
 }
 
-func (q *RawMultiQuestion) Verify() Report {
+func (q *RawMultiQuestion) Verify(path string) Report {
 	var report Report
 
 	if q.ID == "" {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingID,
 			"Missing question ID",
-			"multi",
+			path,
 			"",
 		))
 	}
@@ -97,7 +101,7 @@ func (q *RawMultiQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing prompt",
-			"multi",
+			path+".prompt",
 			q.ID,
 		))
 	}
@@ -106,7 +110,7 @@ func (q *RawMultiQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueInvalidDifficulty,
 			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
-			"multi",
+			path+".difficulty",
 			q.ID,
 		))
 	}
@@ -115,7 +119,7 @@ func (q *RawMultiQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"No options provided",
-			"multi",
+			path+".options",
 			q.ID,
 		))
 	}
@@ -124,24 +128,26 @@ func (q *RawMultiQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"No correct answers specified",
-			"multi",
+			path+".answer",
 			q.ID,
 		))
 	}
 
 	// Check all answer indices are valid
-	for _, ans := range q.Answer {
+	for j, ans := range q.Answer {
 		if ans < 0 || ans >= len(q.Options) {
 			report.Errors = append(report.Errors, NewError(
 				IssueInvalidAnswer,
 				fmt.Sprintf("Answer index %d out of range (0-%d)", ans, len(q.Options)-1),
-				"multi",
+				fmt.Sprintf("%s.answer[%d]", path, j),
 				q.ID,
 			))
 			break
 		}
 	}
 
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
 	return report
 }
 
@@ -151,14 +157,14 @@ func (q *RawMultiQuestion) Repair(issue Issue) Report {
 	return report
 }
 
-func (q *RawBoolQuestion) Verify() Report {
+func (q *RawBoolQuestion) Verify(path string) Report {
 	var report Report
 
 	if q.ID == "" {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingID,
 			"Missing question ID",
-			"bool",
+			path,
 			"",
 		))
 	}
@@ -167,7 +173,7 @@ func (q *RawBoolQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing prompt",
-			"bool",
+			path+".prompt",
 			q.ID,
 		))
 	}
@@ -176,11 +182,13 @@ func (q *RawBoolQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueInvalidDifficulty,
 			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
-			"bool",
+			path+".difficulty",
 			q.ID,
 		))
 	}
 
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
 	return report
 }
 
@@ -190,14 +198,14 @@ func (q *RawBoolQuestion) Repair(issue Issue) Report {
 	return report
 }
 
-func (q *RawTextQuestion) Verify() Report {
+func (q *RawTextQuestion) Verify(path string) Report {
 	var report Report
 
 	if q.ID == "" {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingID,
 			"Missing question ID",
-			"text",
+			path,
 			"",
 		))
 	}
@@ -206,7 +214,7 @@ func (q *RawTextQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Missing prompt",
-			"text",
+			path+".prompt",
 			q.ID,
 		))
 	}
@@ -215,7 +223,7 @@ func (q *RawTextQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueInvalidDifficulty,
 			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
-			"text",
+			path+".difficulty",
 			q.ID,
 		))
 	}
@@ -224,11 +232,44 @@ func (q *RawTextQuestion) Verify() Report {
 		report.Errors = append(report.Errors, NewError(
 			IssueMissingField,
 			"Must have either expected answer or keywords",
-			"text",
+			path+".keywords",
 			q.ID,
 		))
 	}
 
+	for i, k := range q.Keywords {
+		keyword := fmt.Sprintf("%s.keywords[%d]", path, i)
+
+		if k.Term == "" {
+			report.Errors = append(report.Errors, NewError(
+				IssueMissingField,
+				"Keyword has no term",
+				keyword+".term",
+				q.ID,
+			))
+		}
+
+		if k.Weight < 0 {
+			report.Errors = append(report.Errors, NewError(
+				IssueInvalidFormat,
+				fmt.Sprintf("Keyword weight can't be negative: %v", k.Weight),
+				keyword+".weight",
+				q.ID,
+			))
+		}
+
+		if j := slices.Index(k.Synonyms, ""); j >= 0 {
+			report.Warnings = append(report.Warnings, NewWarning(
+				IssueMissingField,
+				"Keyword has an empty synonym",
+				fmt.Sprintf("%s.synonyms[%d]", keyword, j),
+				q.ID,
+			))
+		}
+	}
+
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
 	return report
 }
 
@@ -237,3 +278,196 @@ func (q *RawTextQuestion) Repair(issue Issue) Report {
 
 	return report
 }
+
+func (q *RawShortQuestion) Verify(path string) Report {
+	var report Report
+
+	if q.ID == "" {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingID,
+			"Missing question ID",
+			path,
+			"",
+		))
+	}
+
+	if q.Prompt == "" {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingField,
+			"Missing prompt",
+			path+".prompt",
+			q.ID,
+		))
+	}
+
+	if engine.ParseDifficulty(q.Difficulty) == 0 {
+		report.Errors = append(report.Errors, NewError(
+			IssueInvalidDifficulty,
+			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
+			path+".difficulty",
+			q.ID,
+		))
+	}
+
+	if len(q.Accepted) == 0 && len(q.Patterns) == 0 && q.Number == nil {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingField,
+			"Must have accepted answers, patterns or a number",
+			path,
+			q.ID,
+		))
+	}
+
+	for i, a := range q.Accepted {
+		if strings.TrimSpace(a) == "" {
+			report.Warnings = append(report.Warnings, NewWarning(
+				IssueMissingField,
+				fmt.Sprintf("Empty accepted answer at index %d", i),
+				fmt.Sprintf("%s.accepted[%d]", path, i),
+				q.ID,
+			))
+		}
+	}
+
+	for i, p := range q.Patterns {
+		if _, err := engine.CompileShortPattern(p, q.CaseSensitive); err != nil {
+			report.Errors = append(report.Errors, NewError(
+				IssueInvalidFormat,
+				fmt.Sprintf("Pattern at index %d doesn't compile: %v", i, err),
+				fmt.Sprintf("%s.patterns[%d]", path, i),
+				q.ID,
+			))
+		}
+	}
+
+	if q.Tolerance < 0 {
+		report.Errors = append(report.Errors, NewError(
+			IssueInvalidAnswer,
+			fmt.Sprintf("Tolerance can't be negative: %v", q.Tolerance),
+			path+".tolerance",
+			q.ID,
+		))
+	}
+
+	if q.Tolerance > 0 && q.Number == nil {
+		report.Warnings = append(report.Warnings, NewWarning(
+			IssueInvalidAnswer,
+			"Tolerance has no number to apply to",
+			path+".tolerance",
+			q.ID,
+		))
+	}
+
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
+	return report
+}
+
+func (q *RawShortQuestion) Repair(issue Issue) Report {
+	var report Report
+
+	return report
+}
+
+func (q *RawOrderQuestion) Verify(path string) Report {
+	var report Report
+
+	if q.ID == "" {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingID,
+			"Missing question ID",
+			path,
+			"",
+		))
+	}
+
+	if q.Prompt == "" {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingField,
+			"Missing prompt",
+			path+".prompt",
+			q.ID,
+		))
+	}
+
+	if engine.ParseDifficulty(q.Difficulty) == 0 {
+		report.Errors = append(report.Errors, NewError(
+			IssueInvalidDifficulty,
+			fmt.Sprintf("Invalid difficulty: %s", q.Difficulty),
+			path+".difficulty",
+			q.ID,
+		))
+	}
+
+	if len(q.Items) < 2 {
+		report.Errors = append(report.Errors, NewError(
+			IssueMissingField,
+			"Needs at least two items to order",
+			path+".items",
+			q.ID,
+		))
+	}
+
+	seen := make(map[string]bool, len(q.Items))
+	for i, item := range q.Items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			report.Errors = append(report.Errors, NewError(
+				IssueMissingField,
+				fmt.Sprintf("Empty item at index %d", i),
+				fmt.Sprintf("%s.items[%d]", path, i),
+				q.ID,
+			))
+			continue
+		}
+
+		// Duplicates look the same on screen, there'd be no telling which goes where
+		if seen[item] {
+			report.Errors = append(report.Errors, NewError(
+				IssueInvalidAnswer,
+				fmt.Sprintf("Duplicate item at index %d: %s", i, item),
+				fmt.Sprintf("%s.items[%d]", path, i),
+				q.ID,
+			))
+		}
+		seen[item] = true
+	}
+
+	report.merge(q.RawNotes.verify(q.Difficulty, path, q.ID))
+
+	return report
+}
+
+func (q *RawOrderQuestion) Repair(issue Issue) Report {
+	var report Report
+
+	return report
+}
+
+// verify checks the optional notes, harder questions
+// should explain themselves
+func (n RawNotes) verify(difficulty, path, ref string) Report {
+	var report Report
+
+	if engine.ParseDifficulty(difficulty) == engine.Senior && n.Explanation == "" {
+		report.Warnings = append(report.Warnings, NewWarning(
+			IssueMissingField,
+			"Senior question has no explanation",
+			path+".explanation",
+			ref,
+		))
+	}
+
+	for i, r := range n.References {
+		if strings.TrimSpace(r) == "" {
+			report.Warnings = append(report.Warnings, NewWarning(
+				IssueMissingField,
+				fmt.Sprintf("Empty reference at index %d", i),
+				fmt.Sprintf("%s.references[%d]", path, i),
+				ref,
+			))
+		}
+	}
+
+	return report
+}
@@ -10,10 +10,12 @@ func (s *Session) HasStarted() bool {
 	return s.GetState() != NotStarted
 }
 
+// GetCurrentQuestion returns the current question as it is
+// shown, with its options in display order
 func (s *Session) GetCurrentQuestion() engine.Question {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.questions[s.currentIndex]
+	return s.permutations[s.currentIndex].Question(s.questions[s.currentIndex])
 }
 
 func (s *Session) GetCurrentIndex() int {
@@ -46,12 +48,26 @@ func (s *Session) GetState() State {
 	return s.state
 }
 
+func (s *Session) GetMode() engine.ModeID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mode
+}
+
 func (s *Session) GetFormat() engine.Format {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.format
 }
 
+func (s *Session) IsPaused() bool {
+	return s.GetState() == Paused
+}
+
+func (s *Session) CanPause() bool {
+	return s.format.Time.CanPause
+}
+
 func (s *Session) IsCompleted() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -73,6 +89,18 @@ func (s *Session) IsAnswered(index int) bool {
 	return s.answers[index] != nil
 }
 
+// GetGradeResult returns the grade of the question at index, nil if unanswered
+func (s *Session) GetGradeResult(index int) engine.GradeResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if index < 0 || index >= len(s.gradeResults) {
+		return nil
+	}
+
+	return s.gradeResults[index]
+}
+
 // GetElapsedTime returns total time since session started
 func (s *Session) GetElapsedTime() time.Duration {
 	s.mu.RLock()
@@ -82,6 +110,10 @@ func (s *Session) GetElapsedTime() time.Duration {
 		return 0
 	}
 
+	if s.state == Paused {
+		return s.pausedAt.Sub(s.startTime)
+	}
+
 	if s.endTime.IsZero() {
 		return time.Since(s.startTime)
 	}
@@ -101,17 +133,36 @@ func (s *Session) GetResults() Result {
 	}
 
 	timeTaken := s.endTime.Sub(s.startTime)
-	if s.endTime.IsZero() {
+	switch {
+	case s.state == Paused:
+		timeTaken = s.pausedAt.Sub(s.startTime)
+	case s.endTime.IsZero():
 		timeTaken = time.Since(s.startTime)
 	}
 
+	questionIDs := make([]string, len(s.questions))
+	for i, q := range s.questions {
+		// Scaling sessions can end before every slot is drawn
+		if q != nil {
+			questionIDs[i] = q.GetID()
+		}
+	}
+
 	return Result{
+		Mode:           s.mode,
+		Format:         s.format,
+		Questions:      s.questions,
+		QuestionIDs:    questionIDs,
+		StartedAt:      s.startTime,
+		EndedAt:        s.endTime,
 		TotalQuestions: len(s.questions),
 		Correct:        correct,
 		Incorrect:      len(s.questions) - correct,
 		Score:          s.score,
 		TimeTaken:      timeTaken,
 		State:          s.state,
+		Difficulty:     s.difficultyReached,
+		Seed:           s.seed,
 		Answers:        s.answers,
 		GradeResults:   s.gradeResults,
 	}
@@ -27,6 +27,7 @@ func (s *Session) checkGameOver() bool {
 func (s *Session) advance() {
 	if s.currentIndex < len(s.questions)-1 {
 		s.currentIndex++
+		s.drawQuestion(s.currentIndex)
 		s.resetQuestionTimer()
 	}
 }
@@ -60,3 +61,24 @@ func (s *Session) applyTimePenalty() {
 		s.timeRemaining = 0
 	}
 }
+
+// attempt describes the answer being graded right now. It's scored at
+// the question's own difficulty, pools fall back to other difficulties
+// and review sessions mix them all
+func (s *Session) attempt() engine.Attempt {
+	at := engine.Attempt{
+		Difficulty: s.questions[s.currentIndex].GetDifficulty(),
+		Streak:     s.combo,
+	}
+	if at.Difficulty == 0 {
+		at.Difficulty = s.difficulty
+	}
+
+	switch s.format.Time.Control {
+	case engine.PerQuestion, engine.PerQuestionWithBonus:
+		at.Remaining = s.timeRemaining
+		at.Limit = s.format.Time.PerQuestion
+	}
+
+	return at
+}
@@ -0,0 +1,123 @@
+package session
+
+import (
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+// Pools holds the questions a scaling session can still draw from
+type Pools map[engine.Difficulty]engine.Questions
+
+func (p Pools) size() int {
+	total := 0
+	for _, qs := range p {
+		total += len(qs)
+	}
+	return total
+}
+
+// NewScalingSession creates a session that starts at the format's difficulty
+// and draws each next question from the difficulty the player has reached
+func NewScalingSession(mode engine.ModeID, format engine.Format, pools Pools, grader engine.GradePolicy) *Session {
+	total := pools.size()
+	if count := format.Question.Count.Int(); count > 0 && count < total {
+		total = count
+	}
+
+	s := NewSession(mode, format, make(engine.Questions, total), grader)
+	s.pools = pools
+
+	if total > 0 {
+		s.drawQuestion(0)
+	}
+
+	return s
+}
+
+// drawQuestion fills the slot at index with a question from the current
+// difficulty, falling back to harder and then easier pools when it runs dry
+func (s *Session) drawQuestion(index int) {
+	if s.pools == nil || s.questions[index] != nil {
+		return
+	}
+
+	order := make([]engine.Difficulty, 0, engine.Senior)
+	for d := s.difficulty; d <= engine.Senior; d++ {
+		order = append(order, d)
+	}
+	for d := s.difficulty - 1; d >= engine.Entry; d-- {
+		order = append(order, d)
+	}
+
+	for _, d := range order {
+		pool := s.pools[d]
+		if len(pool) == 0 {
+			continue
+		}
+
+		s.questions[index] = pool[0]
+		s.pools[d] = pool[1:]
+		s.shuffleOptions(index)
+		return
+	}
+}
+
+// updateDifficulty moves the session up a difficulty once the
+// streak or accuracy window set in the format is reached
+func (s *Session) updateDifficulty(correct bool) {
+	rules := s.format.Progression
+	if rules.Mode != engine.Scaling || s.difficulty >= engine.Senior {
+		return
+	}
+
+	if correct {
+		s.streak++
+	} else {
+		s.streak = 0
+	}
+
+	s.recent = append(s.recent, correct)
+	if rules.Window > 0 && len(s.recent) > rules.Window {
+		s.recent = s.recent[1:]
+	}
+
+	if !s.reachedStreak() && !s.reachedAccuracy() {
+		return
+	}
+
+	s.difficulty++
+	s.difficultyReached = max(s.difficultyReached, s.difficulty)
+
+	// Start counting again at the new difficulty
+	s.streak = 0
+	s.recent = nil
+}
+
+func (s *Session) reachedStreak() bool {
+	streak := s.format.Progression.Streak
+	return streak > 0 && s.streak >= streak
+}
+
+func (s *Session) reachedAccuracy() bool {
+	window := s.format.Progression.Window
+	if window == 0 || len(s.recent) < window {
+		return false
+	}
+
+	correct := 0
+	for _, c := range s.recent {
+		if c {
+			correct++
+		}
+	}
+
+	return float32(correct)/float32(window) >= s.format.Progression.Accuracy
+}
+
+// shuffleOptions picks the display order for the options of
+// the question at index when the format asks for it
+func (s *Session) shuffleOptions(index int) {
+	if !s.format.Question.ShuffleOptions || s.questions[index] == nil {
+		return
+	}
+	s.permutations[index] = engine.NewPermutation(s.questions[index])
+}
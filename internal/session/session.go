@@ -14,11 +14,15 @@ var (
 	ErrAlreadyEnded = errors.New("session already ended")
 	ErrNoNavigation = errors.New("navigation not allowed in this mode")
 	ErrInvalidIndex = errors.New("invalid question index")
+	ErrNoPause      = errors.New("pausing not allowed in this mode")
+	ErrNotPaused    = errors.New("session not paused")
+	ErrAnswered     = errors.New("question already answered")
 )
 
 type Session struct {
 	mu sync.RWMutex
 
+	mode   engine.ModeID
 	format engine.Format
 	state  State
 
@@ -26,38 +30,78 @@ type Session struct {
 	answers      []engine.Answer
 	currentIndex int
 	gradeResults []engine.GradeResult
+	permutations []engine.Permutation // option display order, nil unless shuffled
 
 	score          int
+	combo          int // correct answers in a row
 	livesRemaining int
 
+	// Scaling progression, pools is nil for fixed sessions
+	pools             Pools
+	difficulty        engine.Difficulty
+	difficultyReached engine.Difficulty
+	streak            int
+	recent            []bool
+
+	// Seed the questions were selected and shuffled with, 0 when unknown
+	seed int64
+
 	startTime     time.Time
 	endTime       time.Time
+	pausedAt      time.Time
 	timeRemaining time.Duration
 
 	grader engine.GradePolicy
 }
 
 type Result struct {
+	Mode           engine.ModeID
+	Format         engine.Format
+	Questions      engine.Questions // in pack order, nil where a scaling slot was never drawn
+	QuestionIDs    []string
+	StartedAt      time.Time
+	EndedAt        time.Time
 	TotalQuestions int
 	Correct        int
 	Incorrect      int
 	Score          int
 	TimeTaken      time.Duration
 	State          State
+	Difficulty     engine.Difficulty // Highest difficulty reached
+	Seed           int64             // selection seed, replays the same questions
 	Answers        []engine.Answer
 	GradeResults   []engine.GradeResult
 }
 
-func NewSession(format engine.Format, questions engine.Questions, grader engine.GradePolicy) *Session {
-	return &Session{
+func NewSession(mode engine.ModeID, format engine.Format, questions engine.Questions, grader engine.GradePolicy) *Session {
+	s := &Session{
+		mode:           mode,
 		format:         format,
 		questions:      questions,
 		answers:        make([]engine.Answer, len(questions)),
 		gradeResults:   make([]engine.GradeResult, len(questions)),
+		permutations:   make([]engine.Permutation, len(questions)),
 		state:          NotStarted,
 		livesRemaining: format.Lives.Starting,
 		grader:         grader,
+
+		difficulty:        format.Progression.Difficulty,
+		difficultyReached: format.Progression.Difficulty,
 	}
+
+	for i := range questions {
+		s.shuffleOptions(i)
+	}
+
+	return s
+}
+
+// SetSeed records the seed the session's questions were selected with,
+// so the result can say how to replay them
+func (s *Session) SetSeed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seed = seed
 }
 
 // Begin starts the session (no goroutines, just state initialization)
@@ -85,28 +129,89 @@ func (s *Session) Begin() error {
 	return nil
 }
 
+// Submission is an answer on its way to being graded. Grading can take
+// a while with an external grader, so it runs without holding the session:
+// PrepareAnswer takes the answer, Grade grades it and ApplyGrade records it
+type Submission struct {
+	index    int
+	question engine.Question
+	answer   engine.Answer
+	attempt  engine.Attempt
+	grader   engine.GradePolicy
+}
+
+// Index is the question the answer is for
+func (sub *Submission) Index() int {
+	return sub.index
+}
+
+// Grade grades the answer, it's safe to call from any goroutine
+func (sub *Submission) Grade() engine.GradeResult {
+	return sub.grader.Grade(sub.question, sub.answer, sub.attempt)
+}
+
 // SubmitAnswer handles answer submission synchronously
 func (s *Session) SubmitAnswer(answer engine.Answer) error {
+	sub, err := s.PrepareAnswer(answer)
+	if err != nil {
+		return err
+	}
+	return s.ApplyGrade(sub, sub.Grade())
+}
+
+// PrepareAnswer takes an answer to the current question for grading.
+// Once a question is graded its answer is final, a wrong one can't be
+// taken back by answering again
+func (s *Session) PrepareAnswer(answer engine.Answer) (*Submission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != Running {
+		return nil, ErrNotRunning
+	}
+	if s.gradeResults[s.currentIndex] != nil {
+		return nil, ErrAnswered
+	}
+
+	// Answers are kept in pack order, whatever order the options were shown in
+	answer = s.permutations[s.currentIndex].Answer(answer)
+
+	return &Submission{
+		index:    s.currentIndex,
+		question: s.questions[s.currentIndex],
+		answer:   answer,
+		attempt:  s.attempt(),
+		grader:   s.grader,
+	}, nil
+}
+
+// ApplyGrade records a graded submission. A session that ended while
+// the answer was being graded doesn't take it anymore, nor does a
+// question another submission was graded for in the meantime
+func (s *Session) ApplyGrade(sub *Submission, result engine.GradeResult) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	if s.state != Running {
 		return ErrNotRunning
 	}
+	if s.gradeResults[sub.index] != nil {
+		return ErrAnswered
+	}
 
 	// Store answer
-	s.answers[s.currentIndex] = answer
+	s.answers[sub.index] = sub.answer
+	s.gradeResults[sub.index] = result
 
-	// Grade answer
-	question := s.questions[s.currentIndex]
-	result := s.grader.Grade(question, answer)
-	s.gradeResults[s.currentIndex] = result
+	s.updateDifficulty(result.IsCorrect())
 
 	// Update score and lives
+	s.score += engine.Points(result)
 	if result.IsCorrect() {
-		s.score++
+		s.combo++
 		s.applyTimeBonus()
 	} else {
+		s.combo = 0
 		if s.format.Lives.Enabled && s.format.Lives.LoseOnWrong {
 			s.livesRemaining--
 		}
@@ -119,7 +224,7 @@ func (s *Session) SubmitAnswer(answer engine.Answer) error {
 	}
 
 	// Auto-advance for locked navigation
-	if s.format.Time.Navigation == engine.Locked {
+	if s.format.Time.Navigation == engine.Locked && sub.index == s.currentIndex {
 		s.advance()
 	}
 
@@ -144,6 +249,7 @@ func (s *Session) NextQuestion() error {
 	}
 
 	s.currentIndex++
+	s.drawQuestion(s.currentIndex)
 	return nil
 }
 
@@ -190,3 +296,38 @@ func (s *Session) Tick(elapsed time.Duration) (timeExpired bool, newState State)
 
 	return false, Running
 }
+
+// Pause stops the clock, neither the time remaining
+// nor the elapsed time move until Resume
+func (s *Session) Pause() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != Running {
+		return ErrNotRunning
+	}
+
+	if !s.format.Time.CanPause {
+		return ErrNoPause
+	}
+
+	s.state = Paused
+	s.pausedAt = time.Now()
+	return nil
+}
+
+// Resume restarts the clock after a Pause
+func (s *Session) Resume() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != Paused {
+		return ErrNotPaused
+	}
+
+	// Push the start forward so the pause doesn't count as elapsed
+	s.startTime = s.startTime.Add(time.Since(s.pausedAt))
+	s.pausedAt = time.Time{}
+	s.state = Running
+	return nil
+}
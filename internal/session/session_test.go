@@ -0,0 +1,307 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+func testQuestions() engine.Questions {
+	return engine.Questions{
+		engine.ChoiceQuestion{
+			BaseQuestion: engine.BaseQuestion{ID: "port", Difficulty: engine.Junior},
+			Options:      []string{"21", "22", "23", "80"},
+			Correct:      1,
+		},
+		engine.BoolQuestion{
+			BaseQuestion: engine.BaseQuestion{ID: "nil_map", Difficulty: engine.Senior},
+			Correct:      true,
+		},
+		engine.ChoiceQuestion{
+			BaseQuestion: engine.BaseQuestion{ID: "zero"},
+			Options:      []string{`""`, "nil"},
+			Correct:      0,
+		},
+	}
+}
+
+// testFormat navigates freely, with lives and at mid difficulty
+func testFormat() engine.Format {
+	return engine.Format{
+		Time:        engine.TimeRules{Control: engine.Unlimited, Navigation: engine.Free},
+		Lives:       engine.LifeRules{Enabled: true, Starting: 3, LoseOnWrong: true},
+		Progression: engine.ProgressionRules{Mode: engine.Fixed, Difficulty: engine.Mid},
+		Question:    engine.QuestionRules{ShuffleOptions: true},
+	}
+}
+
+func newTestSession(t *testing.T, format engine.Format) *Session {
+	t.Helper()
+	s := NewSession(engine.StandardMode, format, testQuestions(), &engine.ScoreGrader{})
+	if err := s.Begin(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// answer answers the current question, right or wrong,
+// whatever order its options are shown in
+func answer(s *Session, right bool) engine.Answer {
+	switch q := s.GetCurrentQuestion().(type) {
+	case engine.ChoiceQuestion:
+		if right {
+			return engine.ChoiceAnswer{Selected: q.Correct}
+		}
+		return engine.ChoiceAnswer{Selected: (q.Correct + 1) % len(q.Options)}
+	case engine.BoolQuestion:
+		return engine.BoolAnswer{Answer: q.Correct == right}
+	}
+	return nil
+}
+
+func TestAnswerIsFinal(t *testing.T) {
+	tests := []struct {
+		name          string
+		first, second bool
+	}{
+		{"wrong then right", false, true},
+		{"right then wrong", true, false},
+		{"right twice", true, true},
+		{"wrong twice", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSession(t, testFormat())
+
+			if err := s.SubmitAnswer(answer(s, tt.first)); err != nil {
+				t.Fatal(err)
+			}
+			score, lives := s.GetScore(), s.GetLivesRemaining()
+
+			// Coming back to it in free navigation doesn't reopen it
+			if err := s.NextQuestion(); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.PrevQuestion(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.SubmitAnswer(answer(s, tt.second)); !errors.Is(err, ErrAnswered) {
+				t.Errorf("SubmitAnswer() again error = %v, want ErrAnswered", err)
+			}
+			if s.GetScore() != score || s.GetLivesRemaining() != lives {
+				t.Errorf("answering again moved score %d -> %d, lives %d -> %d", score, s.GetScore(), lives, s.GetLivesRemaining())
+			}
+			if got := s.GetResults().GradeResults[0].IsCorrect(); got != tt.first {
+				t.Errorf("grade correct = %v, want the first answer's %v", got, tt.first)
+			}
+			if correct := s.GetResults().GradeResults[0].IsCorrect(); correct != (score > 0) {
+				t.Errorf("grade correct = %v with a score of %d", correct, score)
+			}
+		})
+	}
+}
+
+func TestGradedTwiceBeforeApplied(t *testing.T) {
+	s := newTestSession(t, testFormat())
+
+	// Two answers out to the grader at once, the first one back counts
+	wrong, err := s.PrepareAnswer(answer(s, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := s.PrepareAnswer(answer(s, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ApplyGrade(wrong, wrong.Grade()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ApplyGrade(right, right.Grade()); !errors.Is(err, ErrAnswered) {
+		t.Errorf("ApplyGrade() of the second error = %v, want ErrAnswered", err)
+	}
+	if res := s.GetResults(); res.GradeResults[0].IsCorrect() || res.Score != 0 {
+		t.Errorf("recorded correct = %v, score %d, want the wrong answer", res.GradeResults[0].IsCorrect(), res.Score)
+	}
+}
+
+func TestScoredAtQuestionDifficulty(t *testing.T) {
+	s := newTestSession(t, testFormat())
+
+	// Junior 150, then senior 300 with a one answer streak, then
+	// a question without a difficulty at the session's mid 200
+	want := []int{150, 150 + 330, 150 + 330 + 240}
+	for i, total := range want {
+		if err := s.SubmitAnswer(answer(s, true)); err != nil {
+			t.Fatal(err)
+		}
+		if s.GetScore() != total {
+			t.Errorf("after question %d score = %d, want %d", i, s.GetScore(), total)
+		}
+		if i < len(want)-1 {
+			if err := s.NextQuestion(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestApplyGradeAfterEnd(t *testing.T) {
+	format := testFormat()
+	format.Time = engine.TimeRules{Control: engine.TotalTime, TotalDuration: time.Second, Navigation: engine.Free}
+	s := newTestSession(t, format)
+
+	sub, err := s.PrepareAnswer(answer(s, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := sub.Grade()
+
+	// Time runs out while the answer is being graded
+	if expired, _ := s.Tick(2 * time.Second); !expired {
+		t.Fatal("time didn't run out")
+	}
+
+	if err := s.ApplyGrade(sub, result); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("ApplyGrade() error = %v, want ErrNotRunning", err)
+	}
+	if res := s.GetResults(); res.Answers[0] != nil || res.Score != 0 {
+		t.Errorf("late grade recorded: answer %v, score %d", res.Answers[0], res.Score)
+	}
+}
+
+func TestLockedGradeAdvancesOnce(t *testing.T) {
+	format := testFormat()
+	format.Time.Navigation = engine.Locked
+	s := newTestSession(t, format)
+
+	sub, err := s.PrepareAnswer(answer(s, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ApplyGrade(sub, sub.Grade()); err != nil {
+		t.Fatal(err)
+	}
+	if s.GetCurrentIndex() != 1 {
+		t.Fatalf("index = %d after answering, want 1", s.GetCurrentIndex())
+	}
+
+	// Applying the same submission again doesn't skip a question
+	if err := s.ApplyGrade(sub, sub.Grade()); !errors.Is(err, ErrAnswered) {
+		t.Errorf("ApplyGrade() again error = %v, want ErrAnswered", err)
+	}
+	if s.GetCurrentIndex() != 1 {
+		t.Errorf("index = %d after applying twice, want 1", s.GetCurrentIndex())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	s := newTestSession(t, testFormat())
+	s.SetSeed(42)
+
+	if err := s.SubmitAnswer(answer(s, true)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.NextQuestion(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SubmitAnswer(answer(s, false)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.NextQuestion(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Through JSON, the way a suspended session is saved
+	data, err := json.Marshal(s.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatal(err)
+	}
+
+	byID := make(map[string]engine.Question)
+	for _, q := range testQuestions() {
+		byID[q.GetID()] = q
+	}
+	fetch := func(ids []string) engine.Questions {
+		var qs engine.Questions
+		for _, id := range ids {
+			if q, ok := byID[id]; ok {
+				qs = append(qs, q)
+			}
+		}
+		return qs
+	}
+
+	restored, err := Restore(snap, fetch, &engine.ScoreGrader{})
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	before, after := s.GetResults(), restored.GetResults()
+	if after.Score != before.Score || after.Seed != 42 || restored.GetLivesRemaining() != s.GetLivesRemaining() {
+		t.Errorf("restored score %d, seed %d, lives %d, want %d, 42, %d",
+			after.Score, after.Seed, restored.GetLivesRemaining(), before.Score, s.GetLivesRemaining())
+	}
+	if restored.GetCurrentIndex() != 2 || restored.GetState() != Running {
+		t.Errorf("restored at question %d, %v", restored.GetCurrentIndex(), restored.GetState())
+	}
+	if !reflect.DeepEqual(after.Answers, before.Answers) {
+		t.Errorf("answers = %v, want %v", after.Answers, before.Answers)
+	}
+	for i := range before.GradeResults {
+		if (after.GradeResults[i] == nil) != (before.GradeResults[i] == nil) ||
+			after.GradeResults[i] != nil && after.GradeResults[i].IsCorrect() != before.GradeResults[i].IsCorrect() {
+			t.Errorf("grade %d = %v, want %v", i, after.GradeResults[i], before.GradeResults[i])
+		}
+	}
+	if !reflect.DeepEqual(restored.GetCurrentQuestion(), s.GetCurrentQuestion()) {
+		t.Errorf("options moved: %v, want %v", restored.GetCurrentQuestion(), s.GetCurrentQuestion())
+	}
+}
+
+func TestRestoreErrors(t *testing.T) {
+	s := newTestSession(t, testFormat())
+	snap := s.Snapshot()
+	fetchAll := func(ids []string) engine.Questions {
+		var qs engine.Questions
+		for _, q := range testQuestions() {
+			if q.GetID() == ids[0] {
+				qs = append(qs, q)
+			}
+		}
+		return qs
+	}
+
+	tests := []struct {
+		name   string
+		change func(snap *Snapshot)
+		fetch  func(ids []string) engine.Questions
+		err    error
+	}{
+		{"completed", func(snap *Snapshot) { snap.State = Completed }, fetchAll, ErrNotRunning},
+		{"index out of range", func(snap *Snapshot) { snap.CurrentIndex = 3 }, fetchAll, ErrInvalidIndex},
+		{"answers missing", func(snap *Snapshot) { snap.Answers = snap.Answers[:1] }, fetchAll, ErrInvalidIndex},
+		{"question gone", func(snap *Snapshot) {}, func([]string) engine.Questions { return nil }, ErrMissingQuestion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snap := snap
+			snap.Answers = append([]engine.AnswerRecord(nil), snap.Answers...)
+			tt.change(&snap)
+			if _, err := Restore(snap, tt.fetch, &engine.ScoreGrader{}); !errors.Is(err, tt.err) {
+				t.Errorf("Restore() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
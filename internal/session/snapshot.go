@@ -0,0 +1,181 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+var ErrMissingQuestion = errors.New("question no longer available")
+
+// Snapshot is everything needed to rebuild an in-progress session.
+// Questions are stored by ID and fetched again on restore
+type Snapshot struct {
+	Mode         engine.ModeID         `json:"mode"`
+	Format       engine.Format         `json:"format"`
+	QuestionIDs  []string              `json:"question_ids"`
+	Answers      []engine.AnswerRecord `json:"answers"`
+	Grades       []engine.GradeRecord  `json:"grades"`
+	CurrentIndex int                   `json:"current_index"`
+	State        State                 `json:"state"`
+	Seed         int64                 `json:"seed,omitempty"`
+
+	// Option display order, answers are stored in pack order either way
+	Permutations []engine.Permutation `json:"permutations,omitempty"`
+
+	Score          int           `json:"score"`
+	Combo          int           `json:"combo"`
+	LivesRemaining int           `json:"lives_remaining"`
+	TimeRemaining  time.Duration `json:"time_remaining"`
+	Elapsed        time.Duration `json:"elapsed"`
+
+	// Scaling progression
+	Pools             map[engine.Difficulty][]string `json:"pools,omitempty"`
+	Difficulty        engine.Difficulty              `json:"difficulty"`
+	DifficultyReached engine.Difficulty              `json:"difficulty_reached"`
+	Streak            int                            `json:"streak"`
+	Recent            []bool                         `json:"recent,omitempty"`
+
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// Snapshot captures the session so it can be restored later
+func (s *Session) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	questionIDs := make([]string, len(s.questions))
+	for i, q := range s.questions {
+		if q != nil {
+			questionIDs[i] = q.GetID()
+		}
+	}
+
+	var pools map[engine.Difficulty][]string
+	if s.pools != nil {
+		pools = make(map[engine.Difficulty][]string, len(s.pools))
+		for d, qs := range s.pools {
+			for _, q := range qs {
+				pools[d] = append(pools[d], q.GetID())
+			}
+		}
+	}
+
+	elapsed := time.Duration(0)
+	switch s.state {
+	case NotStarted:
+	case Paused:
+		elapsed = s.pausedAt.Sub(s.startTime)
+	default:
+		elapsed = time.Since(s.startTime)
+	}
+
+	return Snapshot{
+		Mode:              s.mode,
+		Format:            s.format,
+		QuestionIDs:       questionIDs,
+		Answers:           engine.NewAnswerRecords(s.answers),
+		Grades:            engine.NewGradeRecords(s.gradeResults),
+		CurrentIndex:      s.currentIndex,
+		State:             s.state,
+		Seed:              s.seed,
+		Permutations:      s.permutations,
+		Score:             s.score,
+		Combo:             s.combo,
+		LivesRemaining:    s.livesRemaining,
+		TimeRemaining:     s.timeRemaining,
+		Elapsed:           elapsed,
+		Pools:             pools,
+		Difficulty:        s.difficulty,
+		DifficultyReached: s.difficultyReached,
+		Streak:            s.streak,
+		Recent:            s.recent,
+		SavedAt:           time.Now(),
+	}
+}
+
+// Restore rebuilds a session from a snapshot. fetch looks questions up by
+// ID, usually pack.QuestionIndex.Fetch. The clock picks up where it was
+// left, time spent suspended doesn't count. Paused sessions come back running
+func Restore(snap Snapshot, fetch func(ids []string) engine.Questions, grader engine.GradePolicy) (*Session, error) {
+	if snap.State != Running && snap.State != Paused {
+		return nil, ErrNotRunning
+	}
+
+	n := len(snap.QuestionIDs)
+	if len(snap.Answers) != n || len(snap.Grades) != n || snap.CurrentIndex < 0 || snap.CurrentIndex >= n {
+		return nil, ErrInvalidIndex
+	}
+
+	questions, err := fetchDrawn(snap.QuestionIDs, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools Pools
+	if snap.Pools != nil {
+		pools = make(Pools, len(snap.Pools))
+		for d, ids := range snap.Pools {
+			qs, err := fetchDrawn(ids, fetch)
+			if err != nil {
+				return nil, err
+			}
+			pools[d] = qs
+		}
+	}
+
+	s := NewSession(snap.Mode, snap.Format, questions, grader)
+
+	for i := range n {
+		s.answers[i] = snap.Answers[i].Answer
+		s.gradeResults[i] = snap.Grades[i].Result
+	}
+
+	// Keep the options where the player last saw them
+	if len(snap.Permutations) == n {
+		s.permutations = snap.Permutations
+	}
+
+	s.currentIndex = snap.CurrentIndex
+	s.state = Running
+	s.seed = snap.Seed
+	s.score = snap.Score
+	s.combo = snap.Combo
+	s.livesRemaining = snap.LivesRemaining
+	s.timeRemaining = snap.TimeRemaining
+	s.startTime = time.Now().Add(-snap.Elapsed)
+
+	s.pools = pools
+	s.difficulty = snap.Difficulty
+	s.difficultyReached = snap.DifficultyReached
+	s.streak = snap.Streak
+	s.recent = snap.Recent
+
+	if s.questions[s.currentIndex] == nil {
+		s.drawQuestion(s.currentIndex)
+	}
+
+	return s, nil
+}
+
+// fetchDrawn fetches questions by ID, empty IDs are slots
+// a scaling session hasn't drawn yet and stay nil
+func fetchDrawn(ids []string, fetch func(ids []string) engine.Questions) (engine.Questions, error) {
+	questions := make(engine.Questions, len(ids))
+
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		fetched := fetch([]string{id})
+		if len(fetched) == 0 || fetched[0] == nil {
+			return nil, fmt.Errorf("%w: %s", ErrMissingQuestion, id)
+		}
+		questions[i] = fetched[0]
+	}
+
+	return questions, nil
+}
@@ -8,6 +8,7 @@ const (
 	Completed
 	Failed
 	TimeExpired
+	Paused
 )
 
 func (s State) String() string {
@@ -22,6 +23,8 @@ func (s State) String() string {
 		return "failed"
 	case TimeExpired:
 		return "time expired"
+	case Paused:
+		return "paused"
 	default:
 		return "unknown"
 	}
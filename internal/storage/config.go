@@ -2,22 +2,39 @@ package storage
 
 import (
 	"encoding/json"
+	"maps"
 	"os"
+	"slices"
+
+	"github.com/cheezecakee/ace/internal/engine"
 )
 
 const (
 	settingsFile = "savedata/preferences.json"
-	// statsFile    = "savedata/stats.json"
-	// sessionsFile = "savedata/sessions.json"
+	statsFile    = "savedata/stats.json"
+	sessionsFile = "savedata/sessions.json"
+	reviewFile   = "savedata/review.json"
+	resumeFile   = "savedata/resume.json"
 )
 
 type User struct {
-	Settings Settings `json:"settings"`
+	Settings Settings                 `json:"settings"`
+	Presets  map[string]engine.Custom `json:"presets,omitempty"` // Name -> Custom mode config
 }
 
 type Settings struct {
-	Language    string   `json:"language"`
-	ActivePacks []string `json:"active_packs"`
+	Language    string         `json:"language"`
+	ActivePacks []string       `json:"active_packs"`
+	Grader      GraderSettings `json:"grader"`
+	Seed        int64          `json:"seed,omitempty"` // selection seed, 0 = a new one every session
+}
+
+// GraderSettings point text answers at an external grader,
+// leave both Command and URL empty to grade by keywords
+type GraderSettings struct {
+	Command   []string `json:"command,omitempty"`    // program and its args, gets the request on stdin
+	URL       string   `json:"url,omitempty"`        // local server that takes the request as a POST
+	TimeoutMS int      `json:"timeout_ms,omitempty"` // 0 = engine.DefaultGradeTimeout
 }
 
 func NewUser() *User {
@@ -54,6 +71,20 @@ func (u *User) Save() error {
 	return os.WriteFile(settingsFile, data, 0o644)
 }
 
+// SetPreset stores a custom mode config under name, replacing any preset
+// with the same name. Call Save to persist it
+func (u *User) SetPreset(name string, c engine.Custom) {
+	if u.Presets == nil {
+		u.Presets = make(map[string]engine.Custom)
+	}
+	u.Presets[name] = c
+}
+
+// PresetNames returns the saved preset names in alphabetical order
+func (u *User) PresetNames() []string {
+	return slices.Sorted(maps.Keys(u.Presets))
+}
+
 func (u *User) defaultSettings() {
 	u.Settings.Language = "en"
 	u.Settings.ActivePacks = []string{}
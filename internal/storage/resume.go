@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cheezecakee/ace/internal/session"
+)
+
+// SaveSuspended writes an in-progress session so it can be resumed later
+func SaveSuspended(snap session.Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal suspended session: %w", err)
+	}
+
+	if err := os.WriteFile(resumeFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write suspended session: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSuspended reads the suspended session, ok is false if there is none
+func LoadSuspended() (snap session.Snapshot, ok bool, err error) {
+	data, err := os.ReadFile(resumeFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return snap, false, nil
+		}
+		return snap, false, fmt.Errorf("failed to read suspended session: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return snap, false, fmt.Errorf("failed to unmarshal suspended session: %w", err)
+	}
+
+	return snap, true, nil
+}
+
+func HasSuspended() bool {
+	_, err := os.Stat(resumeFile)
+	return err == nil
+}
+
+func ClearSuspended() error {
+	if err := os.Remove(resumeFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove suspended session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+)
+
+// ReviewDeck holds the spaced repetition state of every question answered so far
+type ReviewDeck struct {
+	Cards map[string]engine.ReviewCard `json:"cards"` // QuestionID -> Card
+}
+
+func NewReviewDeck() *ReviewDeck {
+	return &ReviewDeck{
+		Cards: make(map[string]engine.ReviewCard),
+	}
+}
+
+func (d *ReviewDeck) Load() error {
+	data, err := os.ReadFile(reviewFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read review deck: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, d); err != nil {
+		return fmt.Errorf("failed to unmarshal review deck: %w", err)
+	}
+
+	if d.Cards == nil {
+		d.Cards = make(map[string]engine.ReviewCard)
+	}
+
+	return nil
+}
+
+func (d *ReviewDeck) Save() error {
+	data, err := json.MarshalIndent(d, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review deck: %w", err)
+	}
+
+	if err := os.WriteFile(reviewFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write review deck: %w", err)
+	}
+
+	return nil
+}
+
+// Grade feeds a graded answer into the scheduler
+func (d *ReviewDeck) Grade(questionID string, q engine.Quality, now time.Time) {
+	card, ok := d.Cards[questionID]
+	if !ok {
+		card = engine.NewReviewCard()
+	}
+
+	d.Cards[questionID] = card.Schedule(q, now)
+}
+
+// Due picks the question IDs to review now. Cards that are due come
+// first, most overdue at the top, followed by up to newLimit questions
+// that have never been reviewed
+func (d *ReviewDeck) Due(ids []string, now time.Time, newLimit int) []string {
+	var due, fresh []string
+
+	for _, id := range ids {
+		card, ok := d.Cards[id]
+		switch {
+		case !ok:
+			if len(fresh) < newLimit {
+				fresh = append(fresh, id)
+			}
+		case card.IsDue(now):
+			due = append(due, id)
+		}
+	}
+
+	slices.SortStableFunc(due, func(a, b string) int {
+		return cmp.Compare(d.Cards[a].Due.Unix(), d.Cards[b].Due.Unix())
+	})
+
+	return append(due, fresh...)
+}
@@ -1 +1,121 @@
 package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/session"
+)
+
+// SessionRecord is a finished session as it is stored on disk
+type SessionRecord struct {
+	ID          string                `json:"id"`
+	Mode        engine.ModeID         `json:"mode"`
+	Format      engine.Format         `json:"format"`
+	QuestionIDs []string              `json:"question_ids"`
+	Answers     []engine.AnswerRecord `json:"answers"`
+	Grades      []engine.GradeRecord  `json:"grades"`
+
+	Score     int           `json:"score"`
+	Correct   int           `json:"correct"`
+	TimeTaken time.Duration `json:"time_taken"`
+	State     session.State `json:"state"`
+
+	Difficulty engine.Difficulty `json:"difficulty_reached"`
+	Seed       int64             `json:"seed,omitempty"` // selection seed, 0 for sessions that weren't selected
+
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+func NewSessionRecord(r session.Result) SessionRecord {
+	return SessionRecord{
+		ID:          fmt.Sprintf("s-%x", r.StartedAt.UnixNano()),
+		Mode:        r.Mode,
+		Format:      r.Format,
+		QuestionIDs: r.QuestionIDs,
+		Answers:     engine.NewAnswerRecords(r.Answers),
+		Grades:      engine.NewGradeRecords(r.GradeResults),
+		Score:       r.Score,
+		Correct:     r.Correct,
+		TimeTaken:   r.TimeTaken,
+		State:       r.State,
+		Difficulty:  r.Difficulty,
+		Seed:        r.Seed,
+		StartedAt:   r.StartedAt,
+		EndedAt:     r.EndedAt,
+	}
+}
+
+type History struct {
+	Sessions []SessionRecord `json:"sessions"`
+}
+
+func NewHistory() *History {
+	return &History{
+		Sessions: []SessionRecord{},
+	}
+}
+
+func (h *History) Load() error {
+	data, err := os.ReadFile(sessionsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read session history: %w", err)
+	}
+
+	// Nothing has been played yet
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return fmt.Errorf("failed to unmarshal session history: %w", err)
+	}
+
+	return nil
+}
+
+func (h *History) Save() error {
+	data, err := json.MarshalIndent(h, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session history: %w", err)
+	}
+
+	if err := os.WriteFile(sessionsFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write session history: %w", err)
+	}
+
+	return nil
+}
+
+// Record appends a finished session and writes the history to disk
+func (h *History) Record(r session.Result) error {
+	if !r.State.IsTerminal() {
+		return fmt.Errorf("cannot record session in state %s", r.State)
+	}
+
+	h.Sessions = append(h.Sessions, NewSessionRecord(r))
+	return h.Save()
+}
+
+// RecentQuestionIDs returns the questions asked in the last n sessions
+func (h *History) RecentQuestionIDs(n int) map[string]bool {
+	recent := make(map[string]bool)
+
+	start := max(0, len(h.Sessions)-n)
+	for _, s := range h.Sessions[start:] {
+		for _, id := range s.QuestionIDs {
+			if id != "" {
+				recent[id] = true
+			}
+		}
+	}
+
+	return recent
+}
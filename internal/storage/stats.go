@@ -1,2 +1,163 @@
 // Package storage
 package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/pack"
+)
+
+const unknownBucket = "unknown"
+
+// Bucket counts answers for one slice of the history
+type Bucket struct {
+	Answered int `json:"answered"`
+	Correct  int `json:"correct"`
+}
+
+func (b Bucket) Accuracy() float64 {
+	if b.Answered == 0 {
+		return 0
+	}
+	return float64(b.Correct) / float64(b.Answered)
+}
+
+func (b *Bucket) add(correct bool) {
+	b.Answered++
+	if correct {
+		b.Correct++
+	}
+}
+
+type Stats struct {
+	Sessions int           `json:"sessions"`
+	Overall  Bucket        `json:"overall"`
+	Time     time.Duration `json:"time"` // Total time spent answering
+
+	ByCategory   map[string]Bucket              `json:"by_category"`
+	ByDifficulty map[engine.Difficulty]Bucket   `json:"by_difficulty"`
+	ByType       map[engine.QuestionType]Bucket `json:"by_type"`
+	ByRole       map[pack.Role]Bucket           `json:"by_role"`
+	ByMode       map[engine.ModeID]Bucket       `json:"by_mode"`
+
+	// Highest session score per mode
+	BestScore map[engine.ModeID]int `json:"best_score"`
+
+	// Consecutive correct answers
+	CurrentStreak int `json:"current_streak"`
+	BestStreak    int `json:"best_streak"`
+
+	// Consecutive days with at least one session, ending today
+	DayStreak int `json:"day_streak"`
+}
+
+func NewStats() *Stats {
+	return &Stats{
+		ByCategory:   make(map[string]Bucket),
+		ByDifficulty: make(map[engine.Difficulty]Bucket),
+		ByType:       make(map[engine.QuestionType]Bucket),
+		ByRole:       make(map[pack.Role]Bucket),
+		ByMode:       make(map[engine.ModeID]Bucket),
+		BestScore:    make(map[engine.ModeID]int),
+	}
+}
+
+// BuildStats aggregates the session history, details resolves question IDs
+// to their category, difficulty, type and role
+func BuildStats(h *History, details pack.DetailIndex) *Stats {
+	s := NewStats()
+
+	days := make(map[string]bool)
+
+	for _, rec := range h.Sessions {
+		s.Sessions++
+		s.Time += rec.TimeTaken
+		days[rec.StartedAt.Local().Format(time.DateOnly)] = true
+
+		mode := s.ByMode[rec.Mode]
+		s.BestScore[rec.Mode] = max(s.BestScore[rec.Mode], rec.Score)
+
+		for i, id := range rec.QuestionIDs {
+			// Skipped or never reached
+			if i >= len(rec.Grades) || rec.Grades[i].Result == nil {
+				continue
+			}
+
+			correct := rec.Grades[i].Result.IsCorrect()
+
+			s.Overall.add(correct)
+			mode.add(correct)
+
+			if correct {
+				s.CurrentStreak++
+				s.BestStreak = max(s.BestStreak, s.CurrentStreak)
+			} else {
+				s.CurrentStreak = 0
+			}
+
+			d, ok := details[id]
+			if !ok {
+				addTo(s.ByCategory, unknownBucket, correct)
+				addTo(s.ByRole, pack.Role(unknownBucket), correct)
+
+				// Answers still know what type they were
+				if i < len(rec.Answers) && rec.Answers[i].Answer != nil {
+					addTo(s.ByType, rec.Answers[i].Answer.Type(), correct)
+				}
+				continue
+			}
+
+			addTo(s.ByCategory, d.Category, correct)
+			addTo(s.ByDifficulty, d.Difficulty, correct)
+			addTo(s.ByType, d.Type.ToEngine(), correct)
+			addTo(s.ByRole, d.Role, correct)
+		}
+
+		s.ByMode[rec.Mode] = mode
+	}
+
+	s.DayStreak = dayStreak(days, time.Now())
+
+	return s
+}
+
+func addTo[K comparable](m map[K]Bucket, key K, correct bool) {
+	b := m[key]
+	b.add(correct)
+	m[key] = b
+}
+
+func dayStreak(days map[string]bool, now time.Time) int {
+	streak := 0
+	for day := now.Local(); days[day.Format(time.DateOnly)]; day = day.AddDate(0, 0, -1) {
+		streak++
+	}
+	return streak
+}
+
+// AvgTimePerQuestion is the average time spent per answered question
+func (s *Stats) AvgTimePerQuestion() time.Duration {
+	if s.Overall.Answered == 0 {
+		return 0
+	}
+	return s.Time / time.Duration(s.Overall.Answered)
+}
+
+// Save writes the aggregated stats so other tools can read
+// them without replaying the history
+func (s *Stats) Save() error {
+	data, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats: %w", err)
+	}
+
+	if err := os.WriteFile(statsFile, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write stats: %w", err)
+	}
+
+	return nil
+}
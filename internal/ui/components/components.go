@@ -2,11 +2,14 @@ package components
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cheezecakee/ace/internal/engine"
 )
 
 func LivesView(lives int) string {
@@ -16,6 +19,10 @@ func LivesView(lives int) string {
 	return strings.Repeat("❤️ ", lives)
 }
 
+func ScoreView(score int) string {
+	return fmt.Sprintf("★ %d", score)
+}
+
 func TimerView(d time.Duration) string {
 	if d <= 0 {
 		return "⏱ 0:00"
@@ -95,6 +102,28 @@ func multipleView(options []string, selected []int) string {
 	return b.String()
 }
 
+// PicksView lists every option of a graded multiple choice question,
+// marking the right picks, the wrong ones and the ones that were missed
+func PicksView(options []string, selected []int, picks engine.Picks) string {
+	var b strings.Builder
+
+	for i, opt := range options {
+		marker, note := " ", ""
+		switch {
+		case slices.Contains(picks.Wrong, i):
+			marker, note = "✗", "  (wrong pick)"
+		case slices.Contains(picks.Missed, i):
+			marker, note = "!", "  (missed)"
+		case slices.Contains(selected, i):
+			marker = "✓"
+		}
+
+		b.WriteString("[" + marker + "] " + opt + note + "\n")
+	}
+
+	return b.String()
+}
+
 func boolView(selected []int) string {
 	var b strings.Builder
 
@@ -119,10 +148,60 @@ func TextEntryAnswerView(input string) string {
 	return "Answer:\n" + input + "\n"
 }
 
+// FlashcardView puts what the player wrote next to the expected answer
+func FlashcardView(answer, expected string) string {
+	column := lipgloss.NewStyle().Width(38).MarginRight(2)
+	yours := column.Render("Your answer\n\n" + answer)
+	model := column.Render("Expected answer\n\n" + expected)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, yours, model) + "\n"
+}
+
+// PackErrorView shows why a pack file didn't load, with
+// the offending line of the file under it when it's known
+func PackErrorView(message, snippet string) string {
+	s := "✗ " + message + "\n"
+	if snippet != "" {
+		s += lipgloss.NewStyle().Faint(true).MarginLeft(2).Render(snippet) + "\n"
+	}
+	return s
+}
+
 func QuestionView(q string) string {
 	return q + "\n\n"
 }
 
+func VerdictView(correct bool) string {
+	if correct {
+		return "\n✓ Correct\n"
+	}
+	return "\n✗ Not quite\n"
+}
+
+func HintView(hint string) string {
+	return "Hint: " + hint + "\n"
+}
+
+// NotesView explains an answered question, the correct
+// answer followed by whatever notes the pack has for it
+func NotesView(q engine.Question, notes engine.Notes) string {
+	var b strings.Builder
+	b.WriteString("\nAnswer: " + engine.DescribeAnswer(q, q.GetAnswer()) + "\n")
+
+	if notes.Explanation != "" {
+		b.WriteString(notes.Explanation + "\n")
+	}
+
+	if len(notes.References) > 0 {
+		b.WriteString("See also:\n")
+		for _, r := range notes.References {
+			b.WriteString("  - " + r + "\n")
+		}
+	}
+
+	return b.String()
+}
+
 func NewTextArea(placeholder string, focused bool) textarea.Model {
 	ta := textarea.New()
 	ta.Placeholder = placeholder
@@ -141,3 +220,13 @@ func NewTextArea(placeholder string, focused bool) textarea.Model {
 
 	return ta
 }
+
+// BarView draws a small horizontal bar for a ratio between 0 and 1
+// e.g. "networking   ██████░░░░  60% (6/10)"
+func BarView(label string, labelWidth int, ratio float64, width int, detail string) string {
+	ratio = min(max(ratio, 0), 1)
+	filled := int(ratio*float64(width) + 0.5)
+
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("%-*s %s %3.0f%% %s\n", labelWidth, label, bar, ratio*100, detail)
+}
@@ -2,26 +2,55 @@
 package context
 
 import (
+	"errors"
+	"slices"
+	"time"
+
 	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/grading"
 	"github.com/cheezecakee/ace/internal/pack"
 	"github.com/cheezecakee/ace/internal/session"
 	"github.com/cheezecakee/ace/internal/storage"
 	"github.com/cheezecakee/ace/internal/ui"
 )
 
+var (
+	ErrNoQuestions     = errors.New("no questions found")
+	ErrNothingToResume = errors.New("no suspended session")
+)
+
+const (
+	// How many never seen questions a review session can introduce
+	newReviewQuestions = 10
+
+	// Questions from this many past sessions count as recently seen
+	recentSessions = 3
+)
+
 type Context struct {
 	Keys ui.KeyMap
 
 	Format   engine.Format
 	Session  *session.Session
 	User     *storage.User
+	History  *storage.History
+	Review   *storage.ReviewDeck
 	Metadata *pack.Metadata
 
+	// Pack files that didn't load, the packs screen lists them
+	PackErrors []*pack.LoadError
+
 	QuestionCache pack.QuestionIndex
 	LookupCache   pack.Lookup
+	DetailCache   pack.DetailIndex
 
-	Mode  engine.ModeID
-	Packs map[string]bool // if they are active or not
+	// Grades text answers when the user configured an external grader
+	TextBackend engine.TextBackend
+
+	Mode   engine.ModeID
+	Custom *engine.Custom  // what the custom mode builder edits
+	Seed   int64           // selection seed, 0 picks a new one every session
+	Packs  map[string]bool // if they are active or not
 
 	Styles ui.Styles
 	Width  int
@@ -32,7 +61,13 @@ func NewContext() *Context {
 	user := storage.NewUser()
 	_ = user.Load() // TODO ignore err for now
 
-	allPacks, err := pack.LoadAll()
+	history := storage.NewHistory()
+	_ = history.Load()
+
+	review := storage.NewReviewDeck()
+	_ = review.Load()
+
+	allPacks, failed, err := pack.LoadAll()
 	if err != nil {
 		panic(err)
 	}
@@ -52,12 +87,21 @@ func NewContext() *Context {
 		}
 	}
 
+	// No backend configured means grading by keywords
+	backend, _ := grading.New(user.Settings.Grader.Command, user.Settings.Grader.URL)
+
 	ctx := &Context{
-		Keys:     ui.DefaultKeyMap(),
-		Mode:     engine.StandardMode,
-		User:     user,
-		Metadata: metadata,
-		Packs:    packs,
+		Keys:        ui.DefaultKeyMap(),
+		Mode:        engine.StandardMode,
+		Custom:      engine.NewCustomMode(),
+		User:        user,
+		History:     history,
+		Review:      review,
+		Metadata:    metadata,
+		PackErrors:  failed,
+		TextBackend: backend,
+		Seed:        user.Settings.Seed,
+		Packs:       packs,
 	}
 
 	// Build caches
@@ -72,6 +116,47 @@ type (
 	SetPackMsg    []pack.Pack
 )
 
+// Grader returns the grade policy for mode. Modes that grade by
+// accuracy send text answers to the external grader when there is one
+func (c *Context) Grader(mode engine.ModeID) engine.GradePolicy {
+	grader := engine.GetGrader(mode)
+	if c.TextBackend == nil {
+		return grader
+	}
+
+	if _, ok := grader.(*engine.AccuracyGrader); !ok {
+		return grader
+	}
+
+	return &engine.ExternalGrader{
+		Backend:  c.TextBackend,
+		Timeout:  time.Duration(c.User.Settings.Grader.TimeoutMS) * time.Millisecond,
+		Fallback: grader,
+	}
+}
+
+// GameMode returns the selected mode, the custom
+// mode comes with whatever the builder configured
+func (c *Context) GameMode() engine.Mode {
+	if c.Mode == engine.CustomMode {
+		return c.Custom
+	}
+	return engine.GetGameMode(c.Mode)
+}
+
+// Categories returns every category in the active packs, sorted
+func (c *Context) Categories() []engine.Category {
+	var categories []engine.Category
+	for _, d := range c.DetailCache {
+		category := engine.Category(d.Category)
+		if c.Packs[d.PackID] && d.Category != "" && !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+	slices.Sort(categories)
+	return categories
+}
+
 // GetActivePacks returns slice of active pack IDs
 func (c *Context) GetActivePacks() []string {
 	var active []string
@@ -95,9 +180,212 @@ func (c *Context) TogglePack(packID string) {
 	}
 }
 
+// StartSession builds a session for the given role from the
+// current format and mode, begins it and stores it on the context
+func (c *Context) StartSession(role pack.Role) error {
+	var sess *session.Session
+
+	grader := c.Grader(c.Mode)
+	opt := c.selectOptions()
+
+	if c.Format.Progression.Mode == engine.Scaling {
+		pools := c.scalingPools(role, opt)
+		if len(pools) == 0 {
+			return ErrNoQuestions
+		}
+
+		sess = session.NewScalingSession(
+			c.Mode,
+			c.Format,
+			pools,
+			grader,
+		)
+	} else {
+		questionIDs := c.LookupCache.GetQuestionIDs(
+			c.Format.Progression.Difficulty,
+			role,
+			pack.FromEngineTypes(c.Format.Question.Types),
+		)
+
+		if c.Mode == engine.ReviewMode {
+			questionIDs = c.Review.Due(questionIDs, time.Now(), newReviewQuestions)
+		}
+
+		opt.Count = c.Format.Question.Count.Int()
+		questionIDs = pack.Select(questionIDs, c.DetailCache, opt)
+
+		if len(questionIDs) == 0 {
+			return ErrNoQuestions
+		}
+
+		sess = session.NewSession(
+			c.Mode,
+			c.Format,
+			c.QuestionCache.Fetch(questionIDs),
+			grader,
+		)
+	}
+
+	sess.SetSeed(opt.Seed)
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	c.Session = sess
+	return nil
+}
+
+// RetryMissed starts a session with only the questions result
+// got wrong or left unanswered, in the order they were asked
+func (c *Context) RetryMissed(result session.Result) error {
+	var missed engine.Questions
+	for i, q := range result.Questions {
+		if q == nil {
+			continue
+		}
+		if grade := result.GradeResults[i]; grade == nil || !grade.IsCorrect() {
+			missed = append(missed, q)
+		}
+	}
+
+	if len(missed) == 0 {
+		return ErrNoQuestions
+	}
+
+	// A retry is a fixed list, there is nothing left to scale into
+	format := result.Format
+	format.Progression = engine.BuildProgressionRules(
+		engine.Fixed,
+		format.Progression.Difficulty,
+		engine.ProgressionOptions{},
+	)
+
+	sess := session.NewSession(result.Mode, format, missed, c.Grader(result.Mode))
+	if err := sess.Begin(); err != nil {
+		return err
+	}
+
+	c.Mode = result.Mode
+	c.Format = format
+	c.Session = sess
+	return nil
+}
+
+// scalingPools fetches one bucket of questions per difficulty,
+// from the starting difficulty up to senior
+func (c *Context) scalingPools(role pack.Role, opt pack.SelectOptions) session.Pools {
+	pools := make(session.Pools)
+	packTypes := pack.FromEngineTypes(c.Format.Question.Types)
+
+	// The session stops at the question count itself, pools stay whole
+
+	for d := c.Format.Progression.Difficulty; d <= engine.Senior; d++ {
+		questionIDs := pack.Select(c.LookupCache.GetQuestionIDs(d, role, packTypes), c.DetailCache, opt)
+		if len(questionIDs) == 0 {
+			continue
+		}
+
+		pools[d] = c.QuestionCache.Fetch(questionIDs)
+	}
+
+	return pools
+}
+
+// selectOptions turns the format's question rules into pack.Select options.
+// Without a seed set, a new one is picked and recently seen questions go
+// last. A set seed replays the same selection, so history is left out of it
+func (c *Context) selectOptions() pack.SelectOptions {
+	opt := pack.SelectOptions{
+		Categories: c.Format.Question.CategoryFilter,
+		Randomize:  c.Format.Question.Randomize,
+		Seed:       c.Seed,
+	}
+
+	if opt.Seed != 0 {
+		return opt
+	}
+	opt.Seed = time.Now().UnixNano()
+
+	// Review picks what is due, recently seen or not
+	if c.Mode != engine.ReviewMode {
+		opt.Recent = c.History.RecentQuestionIDs(recentSessions)
+	}
+
+	return opt
+}
+
+// SuspendSession saves the running session so it can be resumed
+// from the menu, finished sessions are left alone
+func (c *Context) SuspendSession() error {
+	if c.Session == nil {
+		return nil
+	}
+
+	if state := c.Session.GetState(); state != session.Running && state != session.Paused {
+		return nil
+	}
+
+	return storage.SaveSuspended(c.Session.Snapshot())
+}
+
+// ResumeSession restores the suspended session and makes it current.
+// A session that can't be restored, its file is corrupt or its questions
+// are gone from the packs, never will be, so it's discarded
+func (c *Context) ResumeSession() error {
+	snap, ok, err := storage.LoadSuspended()
+	if err != nil {
+		return errors.Join(err, storage.ClearSuspended())
+	}
+	if !ok {
+		return ErrNothingToResume
+	}
+
+	sess, err := session.Restore(snap, c.QuestionCache.Fetch, c.Grader(snap.Mode))
+	if err != nil {
+		return errors.Join(err, storage.ClearSuspended())
+	}
+
+	c.Mode = snap.Mode
+	c.Format = snap.Format
+	c.Session = sess
+
+	return storage.ClearSuspended()
+}
+
+// ScheduleReviews feeds the graded answers of the current session into the
+// review scheduler once it has ended, each question is graded a single
+// time however often it was answered
+func (c *Context) ScheduleReviews() error {
+	if c.Session == nil || !c.Session.IsCompleted() {
+		return nil
+	}
+
+	result := c.Session.GetResults()
+	now := time.Now()
+	for i, q := range result.Questions {
+		if q == nil || result.GradeResults[i] == nil {
+			continue
+		}
+		c.Review.Grade(q.GetID(), engine.QualityFromResult(result.GradeResults[i]), now)
+	}
+
+	return c.Review.Save()
+}
+
+// RecordSession saves the current session to the history
+// once it has ended
+func (c *Context) RecordSession() error {
+	if c.Session == nil || !c.Session.IsCompleted() {
+		return nil
+	}
+
+	return c.History.Record(c.Session.GetResults())
+}
+
 func (c *Context) buildCache() {
 	c.QuestionCache = make(pack.QuestionIndex)
 	c.LookupCache = make(pack.Lookup)
+	c.DetailCache = make(pack.DetailIndex)
 
 	activePacks := c.GetActivePacks()
 
@@ -110,6 +398,12 @@ func (c *Context) buildCache() {
 		c.QuestionCache.Generate(*c.Metadata, activePacks)
 		c.LookupCache.Generate(*c.Metadata, activePacks)
 	}
+
+	// Details cover every pack so history stays readable after a pack
+	// is switched off. They're generated from the metadata the packs
+	// folder was just read into, so packs added or edited since the
+	// last run aren't missing from stats, filters and the builder
+	c.DetailCache.Generate(*c.Metadata, c.Metadata.PackIDs())
 }
 
 func (c *Context) RebuildCache() error {
@@ -123,5 +417,9 @@ func (c *Context) RebuildCache() error {
 		return err
 	}
 
+	if err := c.DetailCache.Generate(*c.Metadata, c.Metadata.PackIDs()); err != nil {
+		return err
+	}
+
 	return nil
 }
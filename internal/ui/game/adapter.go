@@ -23,6 +23,12 @@ func NewQuestionUI(
 	case engine.TextEntryQuestion:
 		return NewTextQuestionUI(qt, ctx)
 
+	case engine.ShortAnswerQuestion:
+		return NewShortAnswerQuestionUI(qt, ctx)
+
+	case engine.OrderingQuestion:
+		return NewOrderingQuestionUI(qt, ctx)
+
 	default:
 		panic("unsupported question type")
 	}
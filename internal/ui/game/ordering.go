@@ -0,0 +1,104 @@
+package game
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	ctx "github.com/cheezecakee/ace/internal/ui/context"
+	"github.com/cheezecakee/ace/internal/ui/widgets"
+)
+
+// OrderingQuestionUI lets the player pick an item up with
+// select, carry it up or down and drop it with select again
+type OrderingQuestionUI struct {
+	q      engine.OrderingQuestion
+	ctx    *ctx.Context
+	order  []int // item indices in the order they're shown
+	held   bool
+	widget *widgets.Widget
+}
+
+func NewOrderingQuestionUI(q engine.OrderingQuestion, c *ctx.Context) *OrderingQuestionUI {
+	// Never start out already solved
+	order := rand.Perm(len(q.Items))
+	for len(order) > 1 && slices.IsSorted(order) {
+		order = rand.Perm(len(q.Items))
+	}
+
+	o := &OrderingQuestionUI{
+		q:     q,
+		ctx:   c,
+		order: order,
+	}
+	o.rebuild(0)
+
+	return o
+}
+
+func (o *OrderingQuestionUI) Init() tea.Cmd {
+	return nil
+}
+
+func (o *OrderingQuestionUI) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	if key.Matches(keyMsg, o.ctx.Keys.Select) {
+		o.held = !o.held
+		o.rebuild(int(o.widget.Cursor.Row))
+		return nil
+	}
+
+	dir, ok := widgets.DirectionFromKey(keyMsg, o.ctx.Keys.Up, o.ctx.Keys.Down, o.ctx.Keys.Left, o.ctx.Keys.Right)
+	if !ok {
+		return nil
+	}
+
+	if !o.held {
+		o.widget.Move(dir)
+		return nil
+	}
+
+	// Carry the held item along
+	from := int(o.widget.Cursor.Row)
+	to := from + dir[0]
+	if to < 0 || to >= len(o.order) {
+		return nil
+	}
+	o.order[from], o.order[to] = o.order[to], o.order[from]
+	o.rebuild(to)
+
+	return nil
+}
+
+// rebuild redraws the list in the current order with the cursor on row
+func (o *OrderingQuestionUI) rebuild(row int) {
+	items := make([]widgets.Item, len(o.order))
+	for i, idx := range o.order {
+		label := fmt.Sprintf("%d. %s", i+1, o.q.Items[idx])
+		if o.held && i == row {
+			label += "  ⇅"
+		}
+		items[i] = widgets.NewTextItem(label)
+	}
+
+	o.widget = widgets.NewList(items)
+	o.widget.Cursor.Row = widgets.Row(row)
+}
+
+func (o *OrderingQuestionUI) View() string {
+	return o.widget.Render() + "\nspace: pick up / drop\n"
+}
+
+func (o *OrderingQuestionUI) Submit() (engine.Answer, bool) {
+	return engine.OrderingAnswer{
+		Order: slices.Clone(o.order),
+	}, true
+}
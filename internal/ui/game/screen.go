@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/session"
 	"github.com/cheezecakee/ace/internal/ui"
 	"github.com/cheezecakee/ace/internal/ui/components"
 	ctx "github.com/cheezecakee/ace/internal/ui/context"
@@ -22,13 +23,29 @@ type Screen struct {
 
 	lastTick    time.Time
 	gameStarted bool
+	grading     bool // an answer is out to the grader, keys wait for it
+
+	// Practice mode only
+	showHint bool
+	revealed engine.Question // last answered question, its notes show until the next one
+	verdict  engine.GradeResult
 }
 
 type TickMsg time.Time
 
+// GradedMsg is an answer back from the grader
+type GradedMsg struct {
+	sub    *session.Submission
+	result engine.GradeResult
+}
+
 func NewScreen(c *ctx.Context) *Screen {
 	q := c.Session.GetCurrentQuestion()
 
+	// Hides the binding from help too when the mode doesn't allow it
+	c.Keys.Pause.SetEnabled(c.Session.CanPause())
+	c.Keys.Hint.SetEnabled(c.Session.GetMode() == engine.CustomMode)
+
 	return &Screen{
 		ctx:         c,
 		questionUI:  NewQuestionUI(q, c),
@@ -71,13 +88,34 @@ func (s *Screen) Update(msg tea.Msg) (bool, tea.Cmd) {
 		}
 		cmds = append(cmds, s.tickCmd())
 
+	case GradedMsg:
+		s.grading = false
+		return s.graded(msg)
+
 	case tea.KeyMsg:
+		if s.grading {
+			return false, nil
+		}
+
 		if !s.gameStarted {
 			s.gameStarted = true
 			s.lastTick = time.Now()
 			return false, nil
 		}
 
+		if key.Matches(msg, s.ctx.Keys.Pause) {
+			s.togglePause()
+			return false, nil
+		}
+
+		// Nothing but help gets through while paused
+		if s.ctx.Session.IsPaused() {
+			if key.Matches(msg, s.ctx.Keys.Help) {
+				s.help.ShowAll = !s.help.ShowAll
+			}
+			return false, nil
+		}
+
 		switch {
 		case key.Matches(msg, s.ctx.Keys.NextQuestion):
 			if !s.ctx.Session.CanNavigateBack() {
@@ -99,6 +137,9 @@ func (s *Screen) Update(msg tea.Msg) (bool, tea.Cmd) {
 
 		case key.Matches(msg, s.ctx.Keys.Help):
 			s.help.ShowAll = !s.help.ShowAll
+
+		case key.Matches(msg, s.ctx.Keys.Hint):
+			s.showHint = !s.showHint
 		}
 
 		cmds = append(cmds, s.questionUI.Update(msg))
@@ -107,13 +148,38 @@ func (s *Screen) Update(msg tea.Msg) (bool, tea.Cmd) {
 	return false, tea.Batch(cmds...)
 }
 
+func (s *Screen) togglePause() {
+	if s.ctx.Session.IsPaused() {
+		_ = s.ctx.Session.Resume()
+		return
+	}
+	_ = s.ctx.Session.Pause()
+}
+
+// submit sends the answer off to be graded, an external grader can take
+// seconds so it runs as a command and graded picks the result up
 func (s *Screen) submit() (bool, tea.Cmd) {
 	ans, ok := s.questionUI.Submit()
 	if !ok {
 		return false, nil
 	}
 
-	_ = s.ctx.Session.SubmitAnswer(ans)
+	sub, err := s.ctx.Session.PrepareAnswer(ans)
+	if err != nil {
+		return false, nil
+	}
+
+	s.grading = true
+	return false, func() tea.Msg {
+		return GradedMsg{sub: sub, result: sub.Grade()}
+	}
+}
+
+func (s *Screen) graded(msg GradedMsg) (bool, tea.Cmd) {
+	index := msg.sub.Index()
+	q := s.ctx.Session.GetCurrentQuestion()
+
+	_ = s.ctx.Session.ApplyGrade(msg.sub, msg.result)
 
 	if s.ctx.Session.IsCompleted() {
 		return true, nil
@@ -125,12 +191,21 @@ func (s *Screen) submit() (bool, tea.Cmd) {
 		}
 	}
 
+	// Practice explains the answer straight away
+	if s.ctx.Session.GetMode() == engine.CustomMode {
+		s.revealed = q
+		s.verdict = s.ctx.Session.GetGradeResult(index)
+	}
+
 	return false, nil
 }
 
 func (s *Screen) loadCurrentQuestion() {
 	q := s.ctx.Session.GetCurrentQuestion()
 	s.questionUI = NewQuestionUI(q, s.ctx)
+	s.showHint = false
+	s.revealed = nil
+	s.verdict = nil
 }
 
 func (s *Screen) View() string {
@@ -145,6 +220,9 @@ func (s *Screen) View() string {
 	if format.Lives.Enabled {
 		lives = components.LivesView(s.ctx.Session.GetLivesRemaining())
 	}
+	if s.ctx.Session.GetMode() == engine.RapidMode {
+		lives += components.ScoreView(s.ctx.Session.GetScore())
+	}
 	if format.Time.Control != engine.Unlimited {
 		timer = components.TimerView(s.ctx.Session.GetTimeRemaining())
 	}
@@ -156,9 +234,26 @@ func (s *Screen) View() string {
 	)
 	r.Head = r.Styles.Header.Render(header)
 
-	// Body
-	r.Body = components.QuestionView(q.GetPrompt())
-	r.Body += s.questionUI.View() + "\n"
+	// Body, the prompt stays hidden while paused
+	if s.ctx.Session.IsPaused() {
+		r.Body = components.QuestionView("Paused, press " + s.ctx.Keys.Pause.Help().Key + " to resume")
+	} else {
+		r.Body = components.QuestionView(q.GetPrompt())
+		r.Body += s.questionUI.View() + "\n"
+
+		if s.grading {
+			r.Body += r.Styles.Muted.Render("Grading...") + "\n"
+		}
+		if hint := q.GetNotes().Hint; s.showHint && hint != "" {
+			r.Body += components.HintView(hint)
+		}
+		if s.revealed != nil {
+			if s.verdict != nil {
+				r.Body += components.VerdictView(s.verdict.IsCorrect())
+			}
+			r.Body += components.NotesView(s.revealed, s.revealed.GetNotes())
+		}
+	}
 
 	// Footer
 	total := len(s.ctx.Session.GetResults().Answers)
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	ctx "github.com/cheezecakee/ace/internal/ui/context"
+)
+
+type ShortAnswerQuestionUI struct {
+	q     engine.ShortAnswerQuestion
+	ctx   *ctx.Context
+	input textinput.Model
+}
+
+func NewShortAnswerQuestionUI(q engine.ShortAnswerQuestion, c *ctx.Context) *ShortAnswerQuestionUI {
+	ti := textinput.New()
+	ti.Placeholder = "Type a word or a number..."
+	ti.CharLimit = 100
+	ti.Width = 40
+	ti.Prompt = "> "
+	ti.Focus()
+
+	return &ShortAnswerQuestionUI{
+		q:     q,
+		ctx:   c,
+		input: ti,
+	}
+}
+
+func (s *ShortAnswerQuestionUI) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (s *ShortAnswerQuestionUI) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	return cmd
+}
+
+func (s *ShortAnswerQuestionUI) View() string {
+	return s.input.View()
+}
+
+func (s *ShortAnswerQuestionUI) Submit() (engine.Answer, bool) {
+	text := strings.TrimSpace(s.input.Value())
+
+	if text == "" {
+		// Not ready to submit
+		return nil, false
+	}
+
+	return engine.ShortAnswerAnswer{
+		Text: text,
+	}, true
+}
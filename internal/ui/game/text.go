@@ -1,18 +1,27 @@
 package game
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/ui/components"
 	ctx "github.com/cheezecakee/ace/internal/ui/context"
+	"github.com/cheezecakee/ace/internal/ui/widgets"
 )
 
 type TextQuestionUI struct {
 	q        engine.TextEntryQuestion
 	ctx      *ctx.Context
 	textArea textarea.Model
+
+	// Self grading reveals the expected answer on the first
+	// submit, the second one sends the answer with a rating
+	selfGrade bool
+	ratings   *widgets.Widget // nil until revealed
 }
 
 func NewTextQuestionUI(q engine.TextEntryQuestion, c *ctx.Context) *TextQuestionUI {
@@ -24,9 +33,10 @@ func NewTextQuestionUI(q engine.TextEntryQuestion, c *ctx.Context) *TextQuestion
 	ta.Focus() // Start focused
 
 	return &TextQuestionUI{
-		q:        q,
-		ctx:      c,
-		textArea: ta,
+		q:         q,
+		ctx:       c,
+		textArea:  ta,
+		selfGrade: c.Session.GetFormat().Question.SelfGrade,
 	}
 }
 
@@ -35,6 +45,16 @@ func (t *TextQuestionUI) Init() tea.Cmd {
 }
 
 func (t *TextQuestionUI) Update(msg tea.Msg) tea.Cmd {
+	// Once revealed the answer is locked, only the rating moves
+	if t.ratings != nil {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			if dir, ok := widgets.DirectionFromKey(keyMsg, t.ctx.Keys.Up, t.ctx.Keys.Down, t.ctx.Keys.Left, t.ctx.Keys.Right); ok {
+				t.ratings.Move(dir)
+			}
+		}
+		return nil
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		// Handle focus toggle
 		if key.Matches(keyMsg, t.ctx.Keys.ToggleFocus) {
@@ -54,6 +74,9 @@ func (t *TextQuestionUI) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (t *TextQuestionUI) View() string {
+	if t.ratings != nil {
+		return components.FlashcardView(t.textArea.Value(), t.q.ExpectedAnswer) + "\nHow did you do?\n" + t.ratings.Render()
+	}
 	return t.textArea.View()
 }
 
@@ -65,7 +88,30 @@ func (t *TextQuestionUI) Submit() (engine.Answer, bool) {
 		return nil, false
 	}
 
+	if !t.selfGrade {
+		return engine.TextEntryAnswer{
+			Text: text,
+		}, true
+	}
+
+	if t.ratings == nil {
+		t.reveal()
+		return nil, false
+	}
+
 	return engine.TextEntryAnswer{
-		Text: text,
+		Text:   text,
+		Rating: engine.Ratings[t.ratings.Cursor.Col],
 	}, true
 }
+
+func (t *TextQuestionUI) reveal() {
+	items := make([]widgets.Item, len(engine.Ratings))
+	for i, r := range engine.Ratings {
+		items[i] = widgets.NewTextItem(r.String())
+	}
+
+	t.textArea.Blur()
+	t.ratings = widgets.NewBar(items)
+	t.ratings.Cursor.Col = widgets.Col(slices.Index(engine.Ratings, engine.RatingGood))
+}
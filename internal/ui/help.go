@@ -11,7 +11,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Left, k.Right}, // First column
-		{k.Help, k.Quit},                // Second column
+		{k.Up, k.Down, k.Left, k.Right},   // First column
+		{k.Hint, k.Pause, k.Help, k.Quit}, // Second column
 	}
 }
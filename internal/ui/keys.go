@@ -22,6 +22,8 @@ type KeyMap struct {
 	ToggleFocus  key.Binding // For text entry
 	NextQuestion key.Binding
 	PrevQuestion key.Binding
+	Pause        key.Binding
+	Hint         key.Binding
 
 	// Toggle help
 	Help key.Binding
@@ -51,6 +53,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("shift+tab"),
 			key.WithHelp("shift+tab", "prev question"),
 		),
+		Pause: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "pause"),
+		),
+		Hint: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "hint"),
+		),
 		Up: key.NewBinding(
 			key.WithKeys("k", "up"),
 			key.WithHelp("↑/k", "move up"),
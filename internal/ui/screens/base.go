@@ -30,6 +30,15 @@ func NewModel(ctx *ctx.Context) *Model {
 	}
 }
 
+// NewGameModel skips the menus and starts straight into
+// the game, expects ctx.Session to already be running
+func NewGameModel(ctx *ctx.Context) *Model {
+	return &Model{
+		currentScreen: NewGameScreen(ctx),
+		ctx:           ctx,
+	}
+}
+
 func (m *Model) Init() tea.Cmd {
 	return m.currentScreen.Init()
 }
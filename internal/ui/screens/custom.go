@@ -0,0 +1,483 @@
+package screens
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/ui/context"
+	"github.com/cheezecakee/ace/internal/ui/widgets"
+)
+
+// Rows of the custom mode builder, one per engine.Custom field
+// followed by the preset and start actions
+type customField int
+
+const (
+	fieldControl customField = iota
+	fieldTotalDuration
+	fieldPerQuestion
+	fieldBonus
+	fieldPenalty
+	fieldLifeMode
+	fieldLives
+	fieldProgression
+	fieldStreak
+	fieldWindow
+	fieldAccuracy
+	fieldCategories
+	fieldTypes
+	fieldRandomize
+	fieldShuffleOptions
+	fieldQuestionCount
+	fieldPreset
+	fieldSavePreset
+	fieldStart
+)
+
+// What the builder is currently editing besides the field list
+type customEdit int
+
+const (
+	editNone customEdit = iota
+	editCategories
+	editTypes
+	editPresetName
+)
+
+var (
+	customTimeModes    = []engine.TimeMode{engine.Unlimited, engine.PerQuestion, engine.TotalTime, engine.PerQuestionWithBonus}
+	customLifeModes    = []engine.LifeMode{engine.NoLives, engine.FixedLives, engine.SuddenDeath}
+	customProgressions = []engine.Progression{engine.Fixed, engine.Scaling}
+	customCounts       = []engine.QuestionCount{engine.AllQuestions, engine.Ten, engine.Thirty, engine.Fifty}
+	customTypes        = []engine.QuestionType{engine.Choice, engine.MultipleChoice, engine.TextEntry, engine.Bool, engine.ShortAnswer, engine.Ordering}
+)
+
+type CustomScreen struct {
+	fields *widgets.Widget
+
+	// Bars for the fields that pick from a fixed set
+	control     *widgets.Widget
+	lifeMode    *widgets.Widget
+	progression *widgets.Widget
+	count       *widgets.Widget
+	presets     *widgets.Widget
+
+	categoryList *widgets.Widget
+	typeList     *widgets.Widget
+	categories   []engine.Category
+
+	name    textinput.Model
+	editing customEdit
+	err     error  // Format.Validate result for the current config
+	status  string // feedback from saving and loading presets
+
+	ctx *context.Context
+}
+
+func NewCustomScreen(ctx *context.Context) Screen {
+	ctx.Mode = engine.CustomMode
+
+	name := textinput.New()
+	name.Placeholder = "preset name"
+	name.CharLimit = 32
+
+	m := &CustomScreen{
+		categories: ctx.Categories(),
+		name:       name,
+		ctx:        ctx,
+	}
+	m.load()
+
+	return m
+}
+
+// load builds every widget from ctx.Custom
+func (m *CustomScreen) load() {
+	c := m.ctx.Custom
+
+	m.control = newEnumBar(customTimeModes, c.Control)
+	m.lifeMode = newEnumBar(customLifeModes, c.LifeMode)
+	m.progression = newEnumBar(customProgressions, c.Progression)
+	m.count = newEnumBar(customCounts, c.QuestionCount)
+
+	names := m.ctx.User.PresetNames()
+	items := make([]widgets.Item, 0, len(names))
+	for _, n := range names {
+		items = append(items, widgets.NewTextItem(n))
+	}
+	if len(items) == 0 {
+		items = append(items, widgets.NewTextItem("none saved"))
+	}
+	m.presets = widgets.NewBar(items)
+
+	// An empty category filter means all of them
+	m.categoryList = newCheckboxes(m.categories, func(cat engine.Category) bool {
+		return len(c.Categories) == 0 || slices.Contains(c.Categories, cat)
+	})
+	m.typeList = newCheckboxes(customTypes, func(t engine.QuestionType) bool {
+		return slices.Contains(c.Types, t)
+	})
+
+	m.refresh()
+}
+
+// refresh rebuilds the field labels and validates the config
+func (m *CustomScreen) refresh() {
+	c := m.ctx.Custom
+
+	labels := []string{
+		fieldControl:        row("Time", m.control.Render()),
+		fieldTotalDuration:  row("Total time", seconds(c.TotalDuration)),
+		fieldPerQuestion:    row("Per question", seconds(c.PerQuestion)),
+		fieldBonus:          row("Bonus", seconds(c.Bonus)),
+		fieldPenalty:        row("Penalty", seconds(c.Penalty)),
+		fieldLifeMode:       row("Lives", m.lifeMode.Render()),
+		fieldLives:          row("Starting", fmt.Sprint(c.Lives)),
+		fieldProgression:    row("Progression", m.progression.Render()),
+		fieldStreak:         row("Streak", fmt.Sprint(c.Streak)),
+		fieldWindow:         row("Window", fmt.Sprint(c.Window)),
+		fieldAccuracy:       row("Accuracy", fmt.Sprintf("%.0f%%", c.Accuracy*100)),
+		fieldCategories:     row("Categories", joinSet(c.Categories, "all")),
+		fieldTypes:          row("Types", joinSet(c.Types, "none")),
+		fieldRandomize:      row("Randomize", onOff(c.Randomize)),
+		fieldShuffleOptions: row("Shuffle options", onOff(c.ShuffleOptions)),
+		fieldQuestionCount:  row("Questions", m.count.Render()),
+		fieldPreset:         row("Load preset", m.presets.Render()),
+		fieldSavePreset:     "Save preset",
+		fieldStart:          "Start",
+	}
+
+	items := make([]widgets.Item, 0, len(labels))
+	for _, l := range labels {
+		items = append(items, widgets.NewTextItem(l))
+	}
+
+	cursor := widgets.Cursor{}
+	if m.fields != nil {
+		cursor = m.fields.Cursor
+	}
+	m.fields = widgets.NewList(items)
+	m.fields.Cursor = cursor
+
+	// Difficulty only sets where progression starts, any will do to validate
+	m.err = c.Format(engine.Entry).Validate()
+}
+
+func (m *CustomScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m *CustomScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch m.editing {
+	case editCategories, editTypes:
+		return m.updateCheckboxes(keyMsg)
+	case editPresetName:
+		return m.updatePresetName(keyMsg)
+	}
+
+	field := customField(m.fields.Cursor.Row)
+
+	switch {
+	case key.Matches(keyMsg, m.ctx.Keys.Up):
+		m.fields.Move(widgets.Top)
+
+	case key.Matches(keyMsg, m.ctx.Keys.Down):
+		m.fields.Move(widgets.Down)
+
+	case key.Matches(keyMsg, m.ctx.Keys.Left):
+		m.adjust(field, -1)
+
+	case key.Matches(keyMsg, m.ctx.Keys.Right):
+		m.adjust(field, 1)
+
+	case key.Matches(keyMsg, m.ctx.Keys.Select), key.Matches(keyMsg, m.ctx.Keys.Submit):
+		return m.activate(field)
+
+	case key.Matches(keyMsg, m.ctx.Keys.Back):
+		return NewMenu(m.ctx), nil
+	}
+
+	return m, nil
+}
+
+// adjust steps the value of field in dir, -1 for left and 1 for right
+func (m *CustomScreen) adjust(field customField, dir int) {
+	c := m.ctx.Custom
+	move := widgets.Right
+	if dir < 0 {
+		move = widgets.Left
+	}
+
+	switch field {
+	case fieldControl:
+		m.control.Move(move)
+		c.Control = customTimeModes[m.control.Cursor.Col]
+	case fieldTotalDuration:
+		c.TotalDuration = max(0, c.TotalDuration+dir*60)
+	case fieldPerQuestion:
+		c.PerQuestion = max(0, c.PerQuestion+dir*5)
+	case fieldBonus:
+		c.Bonus = max(0, c.Bonus+dir*5)
+	case fieldPenalty:
+		c.Penalty = max(0, c.Penalty+dir*5)
+	case fieldLifeMode:
+		m.lifeMode.Move(move)
+		c.LifeMode = customLifeModes[m.lifeMode.Cursor.Col]
+	case fieldLives:
+		c.Lives = max(0, c.Lives+dir)
+	case fieldProgression:
+		m.progression.Move(move)
+		c.Progression = customProgressions[m.progression.Cursor.Col]
+	case fieldStreak:
+		c.Streak = max(0, c.Streak+dir)
+	case fieldWindow:
+		c.Window = max(0, c.Window+dir)
+	case fieldAccuracy:
+		// Round so repeated steps don't drift off the 5% marks
+		accuracy := math.Round(float64(c.Accuracy)*20+float64(dir)) / 20
+		c.Accuracy = float32(min(1, max(0, accuracy)))
+	case fieldRandomize:
+		c.Randomize = !c.Randomize
+	case fieldShuffleOptions:
+		c.ShuffleOptions = !c.ShuffleOptions
+	case fieldQuestionCount:
+		m.count.Move(move)
+		c.QuestionCount = customCounts[m.count.Cursor.Col]
+	case fieldPreset:
+		m.presets.Move(move)
+	default:
+		return
+	}
+
+	m.refresh()
+}
+
+func (m *CustomScreen) activate(field customField) (Screen, tea.Cmd) {
+	switch field {
+	case fieldCategories:
+		if len(m.categories) > 0 {
+			m.editing = editCategories
+		}
+
+	case fieldTypes:
+		m.editing = editTypes
+
+	case fieldRandomize, fieldShuffleOptions:
+		m.adjust(field, 1)
+
+	case fieldPreset:
+		item, ok := m.presets.GetItem()
+		preset, found := m.ctx.User.Presets[item.Label]
+		if !ok || !found {
+			return m, nil
+		}
+		// Copy so editing doesn't change the saved preset
+		preset.Categories = slices.Clone(preset.Categories)
+		preset.Types = slices.Clone(preset.Types)
+		m.ctx.Custom = &preset
+		m.status = fmt.Sprintf("Loaded %q", item.Label)
+		m.load()
+
+	case fieldSavePreset:
+		m.editing = editPresetName
+		m.name.SetValue("")
+		return m, m.name.Focus()
+
+	case fieldStart:
+		if m.err != nil {
+			return m, nil
+		}
+		return NewDifficultyScreen(m.ctx), nil
+	}
+
+	return m, nil
+}
+
+func (m *CustomScreen) updateCheckboxes(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	list := m.typeList
+	if m.editing == editCategories {
+		list = m.categoryList
+	}
+
+	switch {
+	case key.Matches(msg, m.ctx.Keys.Up):
+		list.Move(widgets.Top)
+
+	case key.Matches(msg, m.ctx.Keys.Down):
+		list.Move(widgets.Down)
+
+	case key.Matches(msg, m.ctx.Keys.Select):
+		list.Toggle()
+
+	case key.Matches(msg, m.ctx.Keys.Submit), key.Matches(msg, m.ctx.Keys.Back):
+		c := m.ctx.Custom
+		if m.editing == editCategories {
+			c.Categories = checked(list, m.categories)
+			// Everything ticked is the same as no filter
+			if len(c.Categories) == len(m.categories) {
+				c.Categories = nil
+			}
+		} else {
+			c.Types = checked(list, customTypes)
+		}
+
+		m.editing = editNone
+		m.refresh()
+	}
+
+	return m, nil
+}
+
+func (m *CustomScreen) updatePresetName(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.ctx.Keys.Back):
+		m.editing = editNone
+		m.name.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.ctx.Keys.Submit):
+		name := strings.TrimSpace(m.name.Value())
+		if name == "" {
+			return m, nil
+		}
+
+		m.editing = editNone
+		m.name.Blur()
+
+		if m.err != nil {
+			m.status = "Fix the config before saving it"
+			return m, nil
+		}
+
+		m.ctx.User.SetPreset(name, *m.ctx.Custom)
+		if err := m.ctx.User.Save(); err != nil {
+			m.status = fmt.Sprintf("Could not save preset: %v", err)
+			return m, nil
+		}
+
+		m.status = fmt.Sprintf("Saved %q", name)
+		m.load()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.name, cmd = m.name.Update(msg)
+	return m, cmd
+}
+
+func (m *CustomScreen) View() string {
+	var s strings.Builder
+	s.WriteString("Custom Mode\n\n")
+	s.WriteString(m.fields.Render())
+
+	switch m.editing {
+	case editCategories:
+		s.WriteString("\nCategories\n")
+		s.WriteString(m.categoryList.Render())
+	case editTypes:
+		s.WriteString("\nTypes\n")
+		s.WriteString(m.typeList.Render())
+	case editPresetName:
+		s.WriteString("\n")
+		s.WriteString(m.name.View())
+		s.WriteString("\n")
+	}
+
+	if m.err != nil {
+		s.WriteString("\n")
+		s.WriteString(m.ctx.Styles.Accent.Render("! " + m.err.Error()))
+		s.WriteString("\n")
+	}
+
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.ctx.Styles.Muted.Render(m.status))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n←/→: Change | Space/Enter: Edit | Esc: Back")
+	return s.String()
+}
+
+// newEnumBar builds a bar of options with the cursor on current
+func newEnumBar[T comparable](options []T, current T) *widgets.Widget {
+	items := make([]widgets.Item, 0, len(options))
+	for _, o := range options {
+		items = append(items, widgets.NewTextItem(fmt.Sprint(o)))
+	}
+
+	bar := widgets.NewBar(items)
+	if i := slices.Index(options, current); i >= 0 {
+		bar.Cursor = widgets.Cursor{Col: widgets.Col(i)}
+	}
+
+	return bar
+}
+
+// newCheckboxes builds a checkbox list of values, ticking the ones isChecked reports
+func newCheckboxes[T any](values []T, isChecked func(T) bool) *widgets.Widget {
+	items := make([]widgets.Item, 0, len(values))
+	for _, v := range values {
+		items = append(items, widgets.NewCheckItem(fmt.Sprint(v)))
+	}
+
+	list := widgets.NewCheckboxList(items)
+	for i, v := range values {
+		if isChecked(v) {
+			list.Selection.Select(widgets.Cursor{Row: widgets.Row(i)})
+		}
+	}
+
+	return list
+}
+
+// checked returns the values ticked in list, in list order
+func checked[T any](list *widgets.Widget, values []T) []T {
+	var picked []T
+	for i, v := range values {
+		if list.Selection.IsSelected(widgets.Cursor{Row: widgets.Row(i)}) {
+			picked = append(picked, v)
+		}
+	}
+	return picked
+}
+
+func joinSet[T any](set []T, empty string) string {
+	if len(set) == 0 {
+		return empty
+	}
+
+	parts := make([]string, 0, len(set))
+	for _, v := range set {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// row lines a field's name and value up with the rows around it
+func row(name, value string) string {
+	return fmt.Sprintf("%-16s%s", name, value)
+}
+
+func seconds(n int) string {
+	return fmt.Sprintf("%ds", n)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -53,13 +53,15 @@ func (m *DifficultyScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			row := int(m.widget.Cursor.Row)
 			selected := m.difficulties[row]
 
-			mode := engine.GetGameMode(m.ctx.Mode)
-			m.ctx.Format = mode.Format(selected)
+			m.ctx.Format = m.ctx.GameMode().Format(selected)
 
 			return NewRoleScreen(m.ctx), nil
 		}
 
 		if key.Matches(msg, m.ctx.Keys.Back) {
+			if m.ctx.Mode == engine.CustomMode {
+				return NewCustomScreen(m.ctx), nil
+			}
 			return NewMenu(m.ctx), nil
 		}
 	}
@@ -26,7 +26,9 @@ func (m *GameScreen) Init() tea.Cmd {
 func (m *GameScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	done, cmd := m.game.Update(msg)
 	if done {
-		return NewCompleteScreen(m.ctx), nil
+		_ = m.ctx.RecordSession()
+		_ = m.ctx.ScheduleReviews()
+		return NewResultsScreen(m.ctx), nil
 	}
 	return m, cmd
 }
@@ -1,12 +1,14 @@
 package screens
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/storage"
 	"github.com/cheezecakee/ace/internal/ui/context"
 	"github.com/cheezecakee/ace/internal/ui/widgets"
 )
@@ -14,6 +16,7 @@ import (
 type Menu struct {
 	widget *widgets.Widget
 	ctx    *context.Context
+	status string
 }
 
 func NewMenu(ctx *context.Context) Screen {
@@ -30,9 +33,33 @@ func NewMenu(ctx *context.Context) Screen {
 				return ModeDifficultyScreen(engine.HardcoreMode)(ctx)
 			}),
 			widgets.NewButtonItem("Custom", func() any {
-				return ModeDifficultyScreen(engine.CustomMode)(ctx)
+				return NewCustomScreen(ctx)
+			}),
+			widgets.NewButtonItem("Review", func() any {
+				// Review picks due questions from every difficulty
+				ctx.Mode = engine.ReviewMode
+				ctx.Format = engine.GetGameMode(engine.ReviewMode).Format(0)
+				return NewRoleScreen(ctx)
+			}),
+			widgets.NewButtonItem("Flashcards", func() any {
+				return ModeDifficultyScreen(engine.FlashcardMode)(ctx)
 			}),
 		},
+	}
+
+	if storage.HasSuspended() {
+		items = append(items, []widgets.Item{widgets.NewButtonItem("Resume", func() any {
+			if err := ctx.ResumeSession(); err != nil {
+				// The session was discarded, the menu comes back without Resume
+				menu := NewMenu(ctx).(*Menu)
+				menu.status = fmt.Sprintf("Could not resume the session: %v", err)
+				return menu
+			}
+			return NewGameScreen(ctx)
+		})})
+	}
+
+	items = append(items, [][]widgets.Item{
 		{widgets.NewButtonItem("Quick Start", func() any {
 			return NewDifficultyScreen(ctx)
 		})},
@@ -40,16 +67,15 @@ func NewMenu(ctx *context.Context) Screen {
 			return NewPacksScreen(ctx)
 		})},
 		{widgets.NewButtonItem("Stats", func() any {
-			// TODO: implement stats screen
-			return NewMenu(ctx)
+			return NewStatsScreen(ctx)
 		})},
 		{widgets.NewButtonItem("Settings", func() any {
 			return NewSettingsScreen(ctx)
 		})},
-	}
+	}...)
 
 	return &Menu{
-		widget: widgets.NewGrid(items, 4),
+		widget: widgets.NewGrid(items, 6),
 		ctx:    ctx,
 	}
 }
@@ -83,6 +109,12 @@ func (m *Menu) View() string {
 	s.WriteString("Menu\n\n")
 	s.WriteString(m.widget.Render())
 	s.WriteString("\n\n")
+
+	if m.status != "" {
+		s.WriteString(m.ctx.Styles.Muted.Render(m.status))
+		s.WriteString("\n")
+	}
+
 	return s.String()
 }
 
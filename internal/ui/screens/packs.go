@@ -1,11 +1,14 @@
 package screens
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/cheezecakee/ace/internal/pack"
+	"github.com/cheezecakee/ace/internal/ui/components"
 	"github.com/cheezecakee/ace/internal/ui/context"
 	"github.com/cheezecakee/ace/internal/ui/widgets"
 )
@@ -103,6 +106,19 @@ func (m *PacksScreen) View() string {
 	s.WriteString("Available            Active\n")
 	s.WriteString("─────────            ──────\n")
 	s.WriteString(m.widget.Render())
+
+	if len(m.ctx.PackErrors) > 0 {
+		s.WriteString("\n\nCouldn't load\n")
+		for _, e := range m.ctx.PackErrors {
+			var snippet string
+			var srcErr *pack.SourceError
+			if errors.As(e, &srcErr) {
+				snippet = srcErr.Snippet
+			}
+			s.WriteString(components.PackErrorView(e.Error(), snippet))
+		}
+	}
+
 	s.WriteString("\n\nSpace/Enter: Toggle | Esc: Back")
 	return s.String()
 }
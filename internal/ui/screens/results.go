@@ -0,0 +1,242 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/session"
+	"github.com/cheezecakee/ace/internal/ui/components"
+	"github.com/cheezecakee/ace/internal/ui/context"
+	"github.com/cheezecakee/ace/internal/ui/widgets"
+)
+
+const (
+	resultsLabelWidth = 16
+	resultsBarWidth   = 20
+)
+
+// ResultsScreen sums up a finished session and lets the
+// player step through every question they were asked
+type ResultsScreen struct {
+	result  session.Result
+	asked   []int // indexes of the questions that were drawn
+	actions *widgets.Widget
+
+	reviewing bool
+	current   int // position in asked while reviewing
+	status    string
+
+	ctx *context.Context
+}
+
+func NewResultsScreen(ctx *context.Context) Screen {
+	result := ctx.Session.GetResults()
+
+	var asked []int
+	for i, q := range result.Questions {
+		if q != nil {
+			asked = append(asked, i)
+		}
+	}
+
+	items := []widgets.Item{
+		widgets.NewTextItem("Review answers"),
+		widgets.NewTextItem("Retry missed"),
+		widgets.NewTextItem("Back to menu"),
+	}
+
+	return &ResultsScreen{
+		result:  result,
+		asked:   asked,
+		actions: widgets.NewBar(items),
+		ctx:     ctx,
+	}
+}
+
+func (m *ResultsScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ResultsScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.reviewing {
+		switch {
+		case key.Matches(keyMsg, m.ctx.Keys.Left), key.Matches(keyMsg, m.ctx.Keys.PrevQuestion):
+			m.current = max(0, m.current-1)
+		case key.Matches(keyMsg, m.ctx.Keys.Right), key.Matches(keyMsg, m.ctx.Keys.NextQuestion):
+			m.current = min(len(m.asked)-1, m.current+1)
+		case key.Matches(keyMsg, m.ctx.Keys.Back), key.Matches(keyMsg, m.ctx.Keys.Submit):
+			m.reviewing = false
+		}
+		return m, nil
+	}
+
+	if dir, ok := widgets.DirectionFromKey(keyMsg, m.ctx.Keys.Up, m.ctx.Keys.Down, m.ctx.Keys.Left, m.ctx.Keys.Right); ok {
+		m.actions.Move(dir)
+		return m, nil
+	}
+
+	if key.Matches(keyMsg, m.ctx.Keys.Back) {
+		return NewMenu(m.ctx), nil
+	}
+
+	if !key.Matches(keyMsg, m.ctx.Keys.Submit) {
+		return m, nil
+	}
+
+	switch m.actions.Cursor.Col {
+	case 0:
+		if len(m.asked) > 0 {
+			m.reviewing = true
+			m.current = 0
+		}
+
+	case 1:
+		if err := m.ctx.RetryMissed(m.result); err != nil {
+			m.status = "Nothing to retry, every question was right"
+			return m, nil
+		}
+		screen := NewGameScreen(m.ctx)
+		return screen, screen.Init()
+
+	case 2:
+		return NewMenu(m.ctx), nil
+	}
+
+	return m, nil
+}
+
+func (m *ResultsScreen) View() string {
+	if m.reviewing {
+		return m.questionView()
+	}
+
+	r := m.result
+
+	var s strings.Builder
+	s.WriteString(resultsTitle(r.State))
+	s.WriteString("\n\n")
+
+	answered := 0
+	for _, a := range r.Answers {
+		if a != nil {
+			answered++
+		}
+	}
+
+	accuracy := 0.0
+	if answered > 0 {
+		accuracy = float64(r.Correct) / float64(answered)
+	}
+
+	s.WriteString(components.BarView("Accuracy", resultsLabelWidth, accuracy, resultsBarWidth, fmt.Sprintf("(%d/%d)", r.Correct, answered)))
+	fmt.Fprintf(&s, "%-*s %d\n", resultsLabelWidth, "Score", r.Score)
+	fmt.Fprintf(&s, "%-*s %d/%d\n", resultsLabelWidth, "Answered", answered, len(m.asked))
+	fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Time", r.TimeTaken.Round(time.Second))
+	fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Result", r.State)
+	if r.Format.Progression.Mode == engine.Scaling {
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Reached", r.Difficulty)
+	}
+	if r.Seed != 0 {
+		fmt.Fprintf(&s, "%-*s %d\n", resultsLabelWidth, "Seed", r.Seed)
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.actions.Render())
+	s.WriteString("\n")
+
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.ctx.Styles.Muted.Render(m.status))
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
+
+// questionView shows one question with the player's answer next to the correct one
+func (m *ResultsScreen) questionView() string {
+	i := m.asked[m.current]
+	q := m.result.Questions[i]
+	answer := m.result.Answers[i]
+	grade := m.result.GradeResults[i]
+
+	var s strings.Builder
+	fmt.Fprintf(&s, "Question %d/%d\n\n", m.current+1, len(m.asked))
+	s.WriteString(components.QuestionView(q.GetPrompt()))
+
+	verdict := "Not answered"
+	if grade != nil {
+		verdict = "Wrong"
+		if grade.IsCorrect() {
+			verdict = "Correct"
+		} else if g, ok := grade.(engine.AccuracyResult); ok && g.Accuracy > 0 {
+			verdict = fmt.Sprintf("Partly right (%.0f%%)", g.Accuracy*100)
+		}
+		if g, ok := grade.(engine.SelfResult); ok {
+			verdict = "Rated " + g.Rating.String()
+		}
+	}
+
+	// Multiple choice marks each option instead of listing both answers
+	mq, multiple := q.(engine.MultipleChoiceQuestion)
+	if r, ok := grade.(engine.PickReporter); ok && multiple {
+		var selected []int
+		if answer != nil {
+			selected, _ = answer.Value().([]int)
+		}
+		s.WriteString(components.PicksView(mq.Options, selected, r.GetPicks()))
+		s.WriteString("\n")
+	} else {
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Your answer", engine.DescribeAnswer(q, answer))
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Correct answer", engine.DescribeAnswer(q, q.GetAnswer()))
+	}
+	fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Result", verdict)
+
+	// Feedback from the grader is shown as is, otherwise
+	// keyword hits show whichever grader was used
+	if g, ok := grade.(engine.AccuracyResult); ok && g.Feedback != "" && !multiple {
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Feedback", g.Feedback)
+	} else if textQ, ok := q.(engine.TextEntryQuestion); ok {
+		text := ""
+		if a, ok := answer.(engine.TextEntryAnswer); ok {
+			text = a.Text
+		}
+		match := engine.MatchText(textQ, text)
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Keywords hit", joinSet(match.Found, "none"))
+		fmt.Fprintf(&s, "%-*s %s\n", resultsLabelWidth, "Keywords missed", joinSet(match.Missing, "none"))
+	}
+
+	if notes := q.GetNotes(); notes.Explanation != "" || len(notes.References) > 0 {
+		s.WriteString("\n")
+		if notes.Explanation != "" {
+			s.WriteString(notes.Explanation + "\n")
+		}
+		for _, r := range notes.References {
+			s.WriteString("  - " + r + "\n")
+		}
+	}
+
+	s.WriteString("\n←/→: Question | Esc: Summary")
+	return s.String()
+}
+
+func resultsTitle(state session.State) string {
+	switch state {
+	case session.Failed:
+		return "Game Over!"
+	case session.TimeExpired:
+		return "Time's Up!"
+	default:
+		return "Quiz Complete!"
+	}
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/cheezecakee/ace/internal/engine"
 	"github.com/cheezecakee/ace/internal/pack"
-	"github.com/cheezecakee/ace/internal/session"
 	"github.com/cheezecakee/ace/internal/ui/context"
 	"github.com/cheezecakee/ace/internal/ui/widgets"
 )
@@ -57,37 +56,18 @@ func (m *RoleScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 			row := int(m.widget.Cursor.Row)
 			role := m.roles[row]
 
-			packTypes := pack.FromEngineTypes(m.ctx.Format.Question.Types)
-
-			questionIDs := m.ctx.LookupCache.GetQuestionIDs(
-				m.ctx.Format.Progression.Difficulty,
-				role,
-				packTypes,
-			)
-
-			if len(questionIDs) == 0 {
-				fmt.Println("error: no questions found")
-				return m, nil
-			}
-
-			questions := m.ctx.QuestionCache.Fetch(questionIDs)
-			grader := engine.GetGrader(m.ctx.Mode)
-
-			sess := session.NewSession(
-				m.ctx.Format,
-				questions,
-				grader,
-			)
-
-			if err := sess.Begin(); err != nil {
+			if err := m.ctx.StartSession(role); err != nil {
+				fmt.Println("error:", err)
 				return m, nil
 			}
 
-			m.ctx.Session = sess
 			return NewGameScreen(m.ctx), nil
 		}
 
 		if key.Matches(msg, m.ctx.Keys.Back) {
+			if m.ctx.Mode == engine.ReviewMode {
+				return NewMenu(m.ctx), nil
+			}
 			return NewDifficultyScreen(m.ctx), nil
 		}
 	}
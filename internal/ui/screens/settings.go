@@ -1,31 +1,69 @@
 package screens
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/cheezecakee/ace/internal/ui/context"
 	"github.com/cheezecakee/ace/internal/ui/widgets"
 )
 
+// Rows of the settings list
+const (
+	settingLanguage = iota
+	settingSeed
+	settingVerify
+	settingReset
+)
+
 type SettingsScreen struct {
 	widget *widgets.Widget
 	ctx    *context.Context
+
+	seed    textinput.Model
+	editing bool
+	status  string
 }
 
 func NewSettingsScreen(ctx *context.Context) Screen {
+	seed := textinput.New()
+	seed.Placeholder = "0 = random"
+	seed.CharLimit = 20
+
+	m := &SettingsScreen{
+		seed: seed,
+		ctx:  ctx,
+	}
+	m.refresh()
+
+	return m
+}
+
+// refresh rebuilds the list labels, keeping the cursor where it was
+func (m *SettingsScreen) refresh() {
+	seed := "random"
+	if m.ctx.Seed != 0 {
+		seed = fmt.Sprint(m.ctx.Seed)
+	}
+
 	items := []widgets.Item{
-		widgets.NewTextItem("Language"),
-		widgets.NewTextItem("Verify/Repair"),
-		widgets.NewTextItem("Reset"),
+		settingLanguage: widgets.NewTextItem("Language"),
+		settingSeed:     widgets.NewTextItem(row("Seed", seed)),
+		settingVerify:   widgets.NewTextItem("Verify/Repair"),
+		settingReset:    widgets.NewTextItem("Reset"),
 	}
 
-	return &SettingsScreen{
-		widget: widgets.NewList(items),
-		ctx:    ctx,
+	cursor := widgets.Cursor{}
+	if m.widget != nil {
+		cursor = m.widget.Cursor
 	}
+	m.widget = widgets.NewList(items)
+	m.widget.Cursor = cursor
 }
 
 func (m *SettingsScreen) Init() tea.Cmd {
@@ -35,6 +73,9 @@ func (m *SettingsScreen) Init() tea.Cmd {
 func (m *SettingsScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updateSeed(msg)
+		}
 
 		if dir, ok := widgets.DirectionFromKey(msg, m.ctx.Keys.Up, m.ctx.Keys.Down, m.ctx.Keys.Left, m.ctx.Keys.Right); ok {
 			m.widget.Move(dir)
@@ -42,6 +83,12 @@ func (m *SettingsScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 		}
 
 		if key.Matches(msg, m.ctx.Keys.Submit) {
+			if m.widget.Cursor.Row == settingSeed {
+				m.editing = true
+				m.seed.SetValue("")
+				return m, m.seed.Focus()
+			}
+
 			// For now, just go back to menu as a test
 			return NewMenu(m.ctx), nil
 		}
@@ -54,9 +101,62 @@ func (m *SettingsScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
 	return m, nil
 }
 
+// updateSeed edits the selection seed, an empty one or 0 picks
+// a new seed every session
+func (m *SettingsScreen) updateSeed(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.ctx.Keys.Back):
+		m.editing = false
+		m.seed.Blur()
+		return m, nil
+
+	case key.Matches(msg, m.ctx.Keys.Submit):
+		var seed int64
+		if value := strings.TrimSpace(m.seed.Value()); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				m.status = fmt.Sprintf("%q isn't a number", value)
+				return m, nil
+			}
+			seed = parsed
+		}
+
+		m.editing = false
+		m.seed.Blur()
+
+		m.ctx.Seed = seed
+		m.ctx.User.Settings.Seed = seed
+		if err := m.ctx.User.Save(); err != nil {
+			m.status = fmt.Sprintf("Could not save settings: %v", err)
+		} else {
+			m.status = ""
+		}
+
+		m.refresh()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.seed, cmd = m.seed.Update(msg)
+	return m, cmd
+}
+
 func (m *SettingsScreen) View() string {
 	var s strings.Builder
 	s.WriteString("Select Settings\n\n")
 	s.WriteString(m.widget.Render())
+
+	if m.editing {
+		s.WriteString("\n")
+		s.WriteString(m.seed.View())
+		s.WriteString("\n")
+	}
+
+	if m.status != "" {
+		s.WriteString("\n")
+		s.WriteString(m.ctx.Styles.Muted.Render(m.status))
+		s.WriteString("\n")
+	}
+
 	return s.String()
 }
@@ -0,0 +1,147 @@
+package screens
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cheezecakee/ace/internal/engine"
+	"github.com/cheezecakee/ace/internal/pack"
+	"github.com/cheezecakee/ace/internal/storage"
+	"github.com/cheezecakee/ace/internal/ui/components"
+	"github.com/cheezecakee/ace/internal/ui/context"
+	"github.com/cheezecakee/ace/internal/ui/widgets"
+)
+
+const (
+	statsLabelWidth = 20
+	statsBarWidth   = 20
+)
+
+var statsSections = []string{"Overview", "Category", "Difficulty", "Type", "Role", "Mode"}
+
+type StatsScreen struct {
+	stats  *storage.Stats
+	widget *widgets.Widget
+	ctx    *context.Context
+}
+
+func NewStatsScreen(ctx *context.Context) Screen {
+	stats := storage.BuildStats(ctx.History, ctx.DetailCache)
+	_ = stats.Save()
+
+	items := make([]widgets.Item, 0, len(statsSections))
+	for _, s := range statsSections {
+		items = append(items, widgets.NewTextItem(s))
+	}
+
+	return &StatsScreen{
+		stats:  stats,
+		widget: widgets.NewBar(items),
+		ctx:    ctx,
+	}
+}
+
+func (m *StatsScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m *StatsScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if dir, ok := widgets.DirectionFromKey(msg, m.ctx.Keys.Up, m.ctx.Keys.Down, m.ctx.Keys.Left, m.ctx.Keys.Right); ok {
+			m.widget.Move(dir)
+			return m, nil
+		}
+
+		if key.Matches(msg, m.ctx.Keys.Back) || key.Matches(msg, m.ctx.Keys.Submit) {
+			return NewMenu(m.ctx), nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m *StatsScreen) View() string {
+	var s strings.Builder
+	s.WriteString("Stats\n\n")
+	s.WriteString(m.widget.Render())
+	s.WriteString("\n\n")
+
+	if m.stats.Sessions == 0 {
+		s.WriteString("No sessions played yet.\n")
+		return s.String()
+	}
+
+	switch statsSections[m.widget.Cursor.Col] {
+	case "Overview":
+		s.WriteString(m.overviewView())
+	case "Category":
+		s.WriteString(bucketsView(m.stats.ByCategory, func(c string) string { return c }))
+	case "Difficulty":
+		s.WriteString(bucketsView(m.stats.ByDifficulty, func(d engine.Difficulty) string { return d.String() }))
+	case "Type":
+		s.WriteString(bucketsView(m.stats.ByType, func(t engine.QuestionType) string { return t.String() }))
+	case "Role":
+		s.WriteString(bucketsView(m.stats.ByRole, func(r pack.Role) string { return string(r) }))
+	case "Mode":
+		s.WriteString(bucketsView(m.stats.ByMode, func(md engine.ModeID) string { return md.String() }))
+	}
+
+	s.WriteString("\n←/→: Section | Esc: Back")
+	return s.String()
+}
+
+func (m *StatsScreen) overviewView() string {
+	var s strings.Builder
+
+	st := m.stats
+	s.WriteString(components.BarView("Accuracy", statsLabelWidth, st.Overall.Accuracy(), statsBarWidth, bucketDetail(st.Overall)))
+	s.WriteString("\n")
+	fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Sessions", st.Sessions)
+	fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Questions answered", st.Overall.Answered)
+	fmt.Fprintf(&s, "%-*s %s\n", statsLabelWidth, "Avg time/question", st.AvgTimePerQuestion().Round(100*time.Millisecond))
+	fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Current streak", st.CurrentStreak)
+	fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Best streak", st.BestStreak)
+	fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Days in a row", st.DayStreak)
+
+	// Only Rapid scores points, everywhere else the score is the correct count
+	if best, ok := st.BestScore[engine.RapidMode]; ok {
+		fmt.Fprintf(&s, "%-*s %d\n", statsLabelWidth, "Best rapid score", best)
+	}
+
+	return s.String()
+}
+
+// bucketsView lists buckets weakest first, so the
+// things to work on are at the top
+func bucketsView[K comparable](buckets map[K]storage.Bucket, label func(K) string) string {
+	keys := make([]K, 0, len(buckets))
+	for k := range buckets {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b K) int {
+		if c := cmp.Compare(buckets[a].Accuracy(), buckets[b].Accuracy()); c != 0 {
+			return c
+		}
+		return cmp.Compare(label(a), label(b))
+	})
+
+	var s strings.Builder
+	for _, k := range keys {
+		b := buckets[k]
+		s.WriteString(components.BarView(label(k), statsLabelWidth, b.Accuracy(), statsBarWidth, bucketDetail(b)))
+	}
+
+	return s.String()
+}
+
+func bucketDetail(b storage.Bucket) string {
+	return fmt.Sprintf("(%d/%d)", b.Correct, b.Answered)
+}